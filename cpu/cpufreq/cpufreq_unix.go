@@ -11,14 +11,23 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package cpufreq provides the current CPU frequency, in MHz, as reported by
+// Package cpufreq provides the current CPU frequency, in MHz. If the system
+// has cpufreq policies, /sys/devices/system/cpu/cpufreq/policyN, the current
+// frequency, along with the policy's governor, driver, available frequencies,
+// and limits, is read from sysfs; otherwise the current frequency is read from
 // /proc/cpuinfo.
 package cpufreq
 
 import (
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/c3sr/joefriday/helpers"
@@ -27,13 +36,22 @@ import (
 
 const procFile = "/proc/cpuinfo"
 
+// The sources of the frequency information.
+const (
+	SourceCPUInfo = "cpuinfo"
+	SourceCPUFreq = "cpufreq"
+)
+
 // Frequency holds information about the current frequency of a system's cpus,
-// in MHz. The reported values are the current speeds as reported by
-// /proc/cpuinfo.
+// in MHz. Source is the source of the current speeds: either cpufreq, in which
+// case Policy holds the information for each active cpufreq policy, or cpuinfo.
+// Inactive policies, whose cpus are all offline, are not included.
 type Frequency struct {
-	Timestamp int64 `json:"timestamp"`
-	Sockets   int32 `json:"sockets"`
-	CPU       []CPU `json:"cpu"`
+	Timestamp int64    `json:"timestamp"`
+	Source    string   `json:"source"`
+	Sockets   int32    `json:"sockets"`
+	CPU       []CPU    `json:"cpu"`
+	Policy    []Policy `json:"policy,omitempty"`
 }
 
 // CPU holds the clock info for a single processor.
@@ -45,20 +63,46 @@ type CPU struct {
 	APICID     int32   `json:"apicid"`
 }
 
+// Policy holds the cpufreq information for a single policy. A policy applies
+// to all of the cpus in CPU; these are the online cpus that share the same
+// clock (affected_cpus). All frequencies are in MHz.
+type Policy struct {
+	ID            int32     `json:"id"`
+	CPU           []int32   `json:"cpu"`
+	Driver        string    `json:"driver"`
+	Governor      string    `json:"governor"`
+	CurMHz        float32   `json:"cur_mhz"`
+	MinMHz        float32   `json:"min_mhz"`
+	MaxMHz        float32   `json:"max_mhz"`
+	CPUInfoMinMHz float32   `json:"cpuinfo_min_mhz"`
+	CPUInfoMaxMHz float32   `json:"cpuinfo_max_mhz"`
+	AvailableMHz  []float32 `json:"available_mhz,omitempty"`
+}
+
 // Profiler is used to process the frequency information.
 type Profiler struct {
 	joe.Procer
 	*joe.Buffer
 	Frequency // this is used too hold the socket/cpu info so that everything doesn't have to be reprocessed.
+
+	sysFSSystemPath string
+	// path of the sysfs cpufreq tree; cached so it doesn't need to be
+	// constantly redone.
+	cpuFreqPath string
+	hasCPUFreq  bool
 }
 
-// Returns an initialized Profiler; ready to use.
+// Returns an initialized Profiler; ready to use. The static cpu information
+// is read from /proc/cpuinfo. If the system has cpufreq policies, the
+// frequencies will be read from sysfs, otherwise they will be read from
+// /proc/cpuinfo.
 func NewProfiler() (prof *Profiler, err error) {
 	proc, err := joe.NewProc(procFile)
 	if err != nil {
 		return nil, err
 	}
 	prof = &Profiler{Procer: proc, Buffer: joe.NewBuffer()}
+	prof.SysFSSystemPath(joe.SysFSSystem)
 	err = prof.InitFrequency()
 	if err != nil {
 		return nil, err
@@ -118,61 +162,50 @@ func (prof *Profiler) InitFrequency() error {
 		if pos+1 < len(prof.Line) {
 			prof.Val = append(prof.Val, joe.TrimTrailingSpaces(prof.Line[pos+1:])...)
 		}
-		if prof.Val[0] == 'a' {
-			if prof.Val[1] == 'p' { // apicid
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.APICID = int32(n)
+		switch string(prof.Val[:nameLen]) {
+		case "apicid":
+			n, err = helpers.ParseUint(prof.Val[nameLen:])
+			if err != nil {
+				return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
 			}
-			continue
-		}
-		if prof.Val[0] == 'c' {
-			if prof.Val[1] == 'o' { // core id
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.CoreID = int32(n)
+			cpu.APICID = int32(n)
+		case "core id":
+			n, err = helpers.ParseUint(prof.Val[nameLen:])
+			if err != nil {
+				return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
 			}
-			continue
-		}
-		if prof.Val[0] == 'p' {
-			if prof.Val[1] == 'h' { // physical id
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.PhysicalID = int32(n)
-				for i := range physIDs {
-					if physIDs[i] == cpu.PhysicalID {
-						pidFound = true
-						break
-					}
-				}
-				if pidFound {
-					pidFound = false // reset for next use
-				} else {
-					// physical id hasn't been encountered yet; add it
-					physIDs = append(physIDs, cpu.PhysicalID)
-				}
-				continue
+			cpu.CoreID = int32(n)
+		case "physical id":
+			n, err = helpers.ParseUint(prof.Val[nameLen:])
+			if err != nil {
+				return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
 			}
-			// processor starts information about a processor.
-			if prof.Val[1] == 'r' { // processor
-				if cpuCnt > 0 {
-					prof.Frequency.CPU = append(prof.Frequency.CPU, cpu)
-				}
-				cpuCnt++
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
+			cpu.PhysicalID = int32(n)
+			for i := range physIDs {
+				if physIDs[i] == cpu.PhysicalID {
+					pidFound = true
+					break
 				}
-				cpu = CPU{Processor: int32(n)}
 			}
+			if pidFound {
+				pidFound = false // reset for next use
+			} else {
+				// physical id hasn't been encountered yet; add it
+				physIDs = append(physIDs, cpu.PhysicalID)
+			}
+		// processor, or cpu number on s390x, starts information about a
+		// processor.
+		case "processor", "cpu number":
+			if cpuCnt > 0 {
+				prof.Frequency.CPU = append(prof.Frequency.CPU, cpu)
+			}
+			cpuCnt++
+			n, err = helpers.ParseUint(prof.Val[nameLen:])
+			if err != nil {
+				return &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
+			}
+			cpu = CPU{Processor: int32(n)}
 		}
-		continue
 	}
 	// append the current processor informatin
 	prof.Frequency.CPU = append(prof.Frequency.CPU, cpu)
@@ -187,9 +220,20 @@ func (prof *Profiler) newFrequency() *Frequency {
 	return f
 }
 
-// Get returns Frequency information.
+// Get returns Frequency information. If the system has cpufreq policies, the
+// information is read from sysfs, otherwise it is read from /proc/cpuinfo.
 func (prof *Profiler) Get() (f *Frequency, err error) {
+	if prof.hasCPUFreq {
+		return prof.getCPUFreq()
+	}
+	return prof.getCPUInfo()
+}
+
+// getCPUInfo returns the Frequency information using the cpu MHz values from
+// /proc/cpuinfo.
+func (prof *Profiler) getCPUInfo() (f *Frequency, err error) {
 	f = prof.newFrequency()
+	f.Source = SourceCPUInfo
 	err = prof.Reset()
 	if err != nil {
 		return nil, err
@@ -230,26 +274,230 @@ func (prof *Profiler) Get() (f *Frequency, err error) {
 		if pos+1 < len(prof.Line) {
 			prof.Val = append(prof.Val, joe.TrimTrailingSpaces(prof.Line[pos+1:])...)
 		}
-		if prof.Val[0] == 'c' {
-			if prof.Val[4] == 'M' { // cpu MHz
-				x, err = strconv.ParseFloat(string(prof.Val[nameLen:]), 32)
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				f.CPU[processor].CPUMHz = float32(x)
+		switch string(prof.Val[:nameLen]) {
+		// ppc64le reports the clock, e.g. 2166.000000MHz, and s390x the dynamic
+		// MHz.
+		case "cpu MHz", "clock", "cpu MHz dynamic":
+			if processor < 0 || processor >= len(f.CPU) {
+				continue
 			}
-			continue
+			x, err = strconv.ParseFloat(strings.TrimSuffix(string(prof.Val[nameLen:]), "MHz"), 32)
+			if err != nil {
+				return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
+			}
+			f.CPU[processor].CPUMHz = float32(x)
+		// processor, or cpu number on s390x, starts information about a
+		// processor.
+		case "processor", "cpu number":
+			processor++
 		}
-		if prof.Val[0] == 'p' {
-			// processor starts information about a processor.
-			if prof.Val[1] == 'r' { // processor
-				processor++
+	}
+	return f, nil
+}
+
+// getCPUFreq returns the Frequency information using the cpufreq policies.
+// Each cpu's CPUMHz is set to the current frequency of the policy that it
+// belongs to.
+func (prof *Profiler) getCPUFreq() (f *Frequency, err error) {
+	f = prof.newFrequency()
+	f.Source = SourceCPUFreq
+	ids, err := policyIDs(prof.cpuFreqPath)
+	if err != nil {
+		return nil, err
+	}
+	f.Policy = make([]Policy, 0, len(ids))
+	for _, id := range ids {
+		var p Policy
+		err = prof.policy(id, &p)
+		if err != nil {
+			// an inactive policy, i.e. all of its cpus are offline, is skipped.
+			if isInactive(err) {
+				continue
+			}
+			return nil, err
+		}
+		f.Policy = append(f.Policy, p)
+		for _, x := range p.CPU {
+			// cpus are usually in processor order; only search if they aren't.
+			if int(x) < len(f.CPU) && f.CPU[x].Processor == x {
+				f.CPU[x].CPUMHz = p.CurMHz
+				continue
+			}
+			for j := range f.CPU {
+				if f.CPU[j].Processor == x {
+					f.CPU[j].CPUMHz = p.CurMHz
+					break
+				}
 			}
 		}
 	}
 	return f, nil
 }
 
+// policyIDs returns the ids of the cpufreq policies in the cpufreq dir, in
+// ascending order.
+func policyIDs(cpuFreqPath string) ([]int32, error) {
+	dirs, err := ioutil.ReadDir(cpuFreqPath)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int32
+	for _, d := range dirs {
+		if !strings.HasPrefix(d.Name(), "policy") {
+			continue
+		}
+		n, err := strconv.Atoi(d.Name()[6:])
+		if err != nil {
+			continue // not a policy dir
+		}
+		ids = append(ids, int32(n))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// policyPath returns the path of the cpufreq policyN dir.
+func policyPath(cpuFreqPath string, id int32) string {
+	return filepath.Join(cpuFreqPath, "policy"+strconv.Itoa(int(id)))
+}
+
+// policy reads the information for the cpufreq policy with the given id.
+// Files that aren't provided by every cpufreq driver, e.g.
+// scaling_available_frequencies, are optional; if a file doesn't exist its
+// value is left at its zero value. If the policy is inactive, the returned
+// error satisfies isInactive.
+func (prof *Profiler) policy(id int32, p *Policy) error {
+	var (
+		v   string
+		err error
+	)
+	path := policyPath(prof.cpuFreqPath, id)
+	p.ID = id
+	p.CPU, err = affectedCPUs(path)
+	if err != nil {
+		return err
+	}
+	p.Driver, err = readFile(filepath.Join(path, "scaling_driver"))
+	if err != nil {
+		return err
+	}
+	p.Governor, err = readFile(filepath.Join(path, "scaling_governor"))
+	if err != nil {
+		return err
+	}
+	p.CurMHz, err = readMHz(filepath.Join(path, "scaling_cur_freq"))
+	if err != nil {
+		return err
+	}
+	p.MinMHz, err = readMHz(filepath.Join(path, "scaling_min_freq"))
+	if err != nil {
+		return err
+	}
+	p.MaxMHz, err = readMHz(filepath.Join(path, "scaling_max_freq"))
+	if err != nil {
+		return err
+	}
+	p.CPUInfoMinMHz, err = readMHz(filepath.Join(path, "cpuinfo_min_freq"))
+	if err != nil {
+		return err
+	}
+	p.CPUInfoMaxMHz, err = readMHz(filepath.Join(path, "cpuinfo_max_freq"))
+	if err != nil {
+		return err
+	}
+	v, err = readFile(filepath.Join(path, "scaling_available_frequencies"))
+	if err != nil {
+		return err
+	}
+	for _, fld := range strings.Fields(v) {
+		n, err := strconv.ParseInt(fld, 10, 64)
+		if err != nil {
+			return &joe.ParseError{Info: "policy" + strconv.Itoa(int(id)) + " scaling_available_frequencies", Err: err}
+		}
+		p.AvailableMHz = append(p.AvailableMHz, kHzToMHz(n))
+	}
+	return nil
+}
+
+// affectedCPUs returns the cpus listed in the policy's affected_cpus file.
+func affectedCPUs(path string) ([]int32, error) {
+	v, err := readFile(filepath.Join(path, "affected_cpus"))
+	if err != nil {
+		return nil, err
+	}
+	var cpus []int32
+	for _, fld := range strings.Fields(v) {
+		n, err := strconv.Atoi(fld)
+		if err != nil {
+			return nil, &joe.ParseError{Info: filepath.Base(path) + " affected_cpus", Err: err}
+		}
+		cpus = append(cpus, int32(n))
+	}
+	return cpus, nil
+}
+
+// readFile returns the contents of a sysfs file with the trailing newline
+// removed. A file that doesn't exist is not an error and results in an empty
+// string.
+func readFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// isInactive returns whether the error is the result of reading a file of an
+// inactive cpufreq policy: a policy whose cpus are all offline. The kernel
+// returns EBUSY for most of an inactive policy's files.
+func isInactive(err error) bool {
+	if perr, ok := err.(*os.PathError); ok && perr.Err == syscall.EBUSY {
+		return true
+	}
+	return false
+}
+
+// readMHz reads a sysfs file containing a frequency, in kHz, and returns it in
+// MHz. A file that doesn't exist is not an error and results in 0.
+func readMHz(path string) (float32, error) {
+	v, err := readFile(path)
+	if err != nil || v == "" {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, &joe.ParseError{Info: filepath.Base(path), Err: err}
+	}
+	return kHzToMHz(n), nil
+}
+
+// kHzToMHz converts a kHz value to MHz.
+func kHzToMHz(n int64) float32 {
+	return float32(float64(n) / 1000)
+}
+
+// SysFSSystemPath enables overriding the default value. This is for testing
+// and should not be used outside of tests. Whether or not the system has
+// cpufreq policies is redetermined using the new path.
+func (prof *Profiler) SysFSSystemPath(s string) {
+	prof.sysFSSystemPath = s
+	prof.cpuFreqPath = filepath.Join(s, "cpu", "cpufreq")
+	prof.hasCPUFreq = false
+	ids, err := policyIDs(prof.cpuFreqPath)
+	if err == nil && len(ids) > 0 {
+		prof.hasCPUFreq = true
+	}
+}
+
+// HasCPUFreq returns whether or not the frequency information is read from
+// the system's cpufreq policies.
+func (prof *Profiler) HasCPUFreq() bool {
+	return prof.hasCPUFreq
+}
+
 var std *Profiler
 var stdMu sync.Mutex
 
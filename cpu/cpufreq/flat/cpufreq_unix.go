@@ -12,8 +12,8 @@
 // limitations under the License.
 
 // Package cpufreq provides the current CPU frequency, in MHz, as reported by
-// /proc/cpuinfo. Instead of returning a Go struct, it returns Flatbuffer
-// serialized bytes. A function to deserialize the Flatbuffer serialized bytes
+// either the cpufreq policies in sysfs or /proc/cpuinfo. Instead of returning
+// a Go struct, it returns Flatbuffer serialized bytes. A function to deserialize the Flatbuffer serialized bytes
 // into a cpufreq.Frequency struct is provided.
 //
 // Note: the package name is cpufreq and not the final element of the import
@@ -85,10 +85,22 @@ func (p *Profiler) Serialize(f *freq.Frequency) []byte {
 		p.Builder.PrependUOffsetT(uoffs[i])
 	}
 	cpusV := p.Builder.EndVector(len(uoffs))
+	uoffs = make([]fb.UOffsetT, len(f.Policy))
+	for i := range f.Policy {
+		uoffs[i] = p.SerializePolicy(&f.Policy[i])
+	}
+	structs.FrequencyStartPolicyVector(p.Builder, len(uoffs))
+	for i := len(uoffs) - 1; i >= 0; i-- {
+		p.Builder.PrependUOffsetT(uoffs[i])
+	}
+	policiesV := p.Builder.EndVector(len(uoffs))
+	source := p.Builder.CreateString(f.Source)
 	structs.FrequencyStart(p.Builder)
 	structs.FrequencyAddTimestamp(p.Builder, f.Timestamp)
 	structs.FrequencyAddSockets(p.Builder, f.Sockets)
 	structs.FrequencyAddCPU(p.Builder, cpusV)
+	structs.FrequencyAddSource(p.Builder, source)
+	structs.FrequencyAddPolicy(p.Builder, policiesV)
 	p.Builder.Finish(structs.FrequencyEnd(p.Builder))
 	b := p.Builder.Bytes[p.Builder.Head():]
 	// copy them (otherwise gets lost in reset)
@@ -109,6 +121,35 @@ func (p *Profiler) SerializeCPU(cpu *freq.CPU) fb.UOffsetT {
 	return structs.CPUEnd(p.Builder)
 }
 
+// SerializePolicy serializes a Policy using flatbuffers and returns the
+// resulting UOffsetT.
+func (p *Profiler) SerializePolicy(pol *freq.Policy) fb.UOffsetT {
+	driver := p.Builder.CreateString(pol.Driver)
+	governor := p.Builder.CreateString(pol.Governor)
+	structs.PolicyStartCPUVector(p.Builder, len(pol.CPU))
+	for i := len(pol.CPU) - 1; i >= 0; i-- {
+		p.Builder.PrependInt32(pol.CPU[i])
+	}
+	cpuV := p.Builder.EndVector(len(pol.CPU))
+	structs.PolicyStartAvailableMHzVector(p.Builder, len(pol.AvailableMHz))
+	for i := len(pol.AvailableMHz) - 1; i >= 0; i-- {
+		p.Builder.PrependFloat32(pol.AvailableMHz[i])
+	}
+	availV := p.Builder.EndVector(len(pol.AvailableMHz))
+	structs.PolicyStart(p.Builder)
+	structs.PolicyAddID(p.Builder, pol.ID)
+	structs.PolicyAddCPU(p.Builder, cpuV)
+	structs.PolicyAddDriver(p.Builder, driver)
+	structs.PolicyAddGovernor(p.Builder, governor)
+	structs.PolicyAddCurMHz(p.Builder, pol.CurMHz)
+	structs.PolicyAddMinMHz(p.Builder, pol.MinMHz)
+	structs.PolicyAddMaxMHz(p.Builder, pol.MaxMHz)
+	structs.PolicyAddCPUInfoMinMHz(p.Builder, pol.CPUInfoMinMHz)
+	structs.PolicyAddCPUInfoMaxMHz(p.Builder, pol.CPUInfoMaxMHz)
+	structs.PolicyAddAvailableMHz(p.Builder, availV)
+	return structs.PolicyEnd(p.Builder)
+}
+
 // Serialize cpufreq.Frequency using the package global profiler.
 func Serialize(f *freq.Frequency) (p []byte, err error) {
 	stdMu.Lock()
@@ -143,6 +184,33 @@ func Deserialize(p []byte) *freq.Frequency {
 		cpu.APICID = fCPU.APICID()
 		f.CPU = append(f.CPU, cpu)
 	}
+	f.Source = string(ff.Source())
+	fPolicy := &structs.Policy{}
+	for i := 0; i < ff.PolicyLength(); i++ {
+		if !ff.Policy(fPolicy, i) {
+			continue
+		}
+		var pol freq.Policy
+		pol.ID = fPolicy.ID()
+		pol.CPU = make([]int32, fPolicy.CPULength())
+		for j := range pol.CPU {
+			pol.CPU[j] = fPolicy.CPU(j)
+		}
+		pol.Driver = string(fPolicy.Driver())
+		pol.Governor = string(fPolicy.Governor())
+		pol.CurMHz = fPolicy.CurMHz()
+		pol.MinMHz = fPolicy.MinMHz()
+		pol.MaxMHz = fPolicy.MaxMHz()
+		pol.CPUInfoMinMHz = fPolicy.CPUInfoMinMHz()
+		pol.CPUInfoMaxMHz = fPolicy.CPUInfoMaxMHz()
+		if l := fPolicy.AvailableMHzLength(); l > 0 {
+			pol.AvailableMHz = make([]float32, l)
+			for j := range pol.AvailableMHz {
+				pol.AvailableMHz[j] = fPolicy.AvailableMHz(j)
+			}
+		}
+		f.Policy = append(f.Policy, pol)
+	}
 	return f
 }
 
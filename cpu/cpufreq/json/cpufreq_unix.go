@@ -12,8 +12,8 @@
 // limitations under the License.
 
 // Package cpufreq provides the current CPU frequency, in MHz, as reported by
-// /proc/cpuinfo. Instead of returning a Go struct, it returns JSON serialized
-// bytes. A function to deserialize the JSON serialized bytes into a
+// either the cpufreq policies in sysfs or /proc/cpuinfo. Instead of returning
+// a Go struct, it returns JSON serialized bytes. A function to deserialize the JSON serialized bytes into a
 // cpufreq.Frequency struct is provided.
 //
 // Note: the package name is cpufreq and not the final element of the import
@@ -0,0 +1,417 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpufreq
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+)
+
+// Stats holds the cpufreq statistics of each active policy: the time spent at
+// each frequency and the frequency transitions. The statistics are aggregated
+// since the policy's statistics were last reset, usually system boot.
+// Inactive policies, whose cpus are all offline, are not included.
+type Stats struct {
+	Timestamp int64         `json:"timestamp"`
+	Policy    []PolicyStats `json:"policy"`
+}
+
+// PolicyStats holds the cpufreq statistics for a single policy. If the
+// policy doesn't have any statistics, e.g. the kernel wasn't built with
+// CONFIG_CPU_FREQ_STAT or the driver doesn't support them, TimeInState and
+// TransTable will be empty.
+//
+// TransTable holds the number of transitions between frequencies:
+// TransTable[i][j] is the number of transitions from TransMHz[i] to
+// TransMHz[j]. The kernel doesn't provide the trans_table if it's larger
+// than a page; in that case TransMHz and TransTable will be empty.
+type PolicyStats struct {
+	ID          int32       `json:"id"`
+	CPU         []int32     `json:"cpu"`
+	TimeInState []StateTime `json:"time_in_state"`
+	TotalTrans  int64       `json:"total_trans"`
+	TransMHz    []float32   `json:"trans_mhz,omitempty"`
+	TransTable  [][]int64   `json:"trans_table,omitempty"`
+}
+
+// StateTime is the amount of time spent at a frequency, in MHz. Time is in
+// USER_HZ units, which is usually 10ms.
+type StateTime struct {
+	MHz  float32 `json:"mhz"`
+	Time int64   `json:"time"`
+}
+
+// StatsProfiler is used to process the cpufreq statistics.
+type StatsProfiler struct {
+	sysFSSystemPath string
+	// path of the sysfs cpufreq tree; cached so it doesn't need to be
+	// constantly redone.
+	cpuFreqPath string
+}
+
+// Returns an initialized StatsProfiler; ready to use.
+func NewStatsProfiler() (prof *StatsProfiler) {
+	prof = &StatsProfiler{}
+	prof.SysFSSystemPath(joe.SysFSSystem)
+	return prof
+}
+
+// Get returns the cpufreq statistics of each of the system's cpufreq
+// policies.
+func (prof *StatsProfiler) Get() (*Stats, error) {
+	ids, err := policyIDs(prof.cpuFreqPath)
+	if err != nil {
+		return nil, err
+	}
+	st := &Stats{Timestamp: time.Now().UTC().UnixNano(), Policy: make([]PolicyStats, 0, len(ids))}
+	for _, id := range ids {
+		var p PolicyStats
+		err = prof.policyStats(id, &p)
+		if err != nil {
+			// an inactive policy, i.e. all of its cpus are offline, is skipped.
+			if isInactive(err) {
+				continue
+			}
+			return nil, err
+		}
+		st.Policy = append(st.Policy, p)
+	}
+	return st, nil
+}
+
+// SysFSSystemPath enables overriding the default value. This is for testing
+// and should not be used outside of tests.
+func (prof *StatsProfiler) SysFSSystemPath(s string) {
+	prof.sysFSSystemPath = s
+	prof.cpuFreqPath = filepath.Join(s, "cpu", "cpufreq")
+}
+
+// policyStats reads the statistics for the cpufreq policy with the given id.
+// If the policy is inactive, the returned error satisfies isInactive.
+func (prof *StatsProfiler) policyStats(id int32, p *PolicyStats) error {
+	var (
+		v   string
+		n   int64
+		err error
+	)
+	path := policyPath(prof.cpuFreqPath, id)
+	p.ID = id
+	p.CPU, err = affectedCPUs(path)
+	if err != nil {
+		return err
+	}
+	path = filepath.Join(path, "stats")
+	v, err = readStatsFile(filepath.Join(path, "time_in_state"))
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(v, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		var st StateTime
+		n, err = strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return &joe.ParseError{Info: "policy" + strconv.Itoa(int(id)) + " time_in_state", Err: err}
+		}
+		st.MHz = kHzToMHz(n)
+		st.Time, err = strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return &joe.ParseError{Info: "policy" + strconv.Itoa(int(id)) + " time_in_state", Err: err}
+		}
+		p.TimeInState = append(p.TimeInState, st)
+	}
+	v, err = readStatsFile(filepath.Join(path, "total_trans"))
+	if err != nil {
+		return err
+	}
+	if v != "" {
+		p.TotalTrans, err = strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return &joe.ParseError{Info: "policy" + strconv.Itoa(int(id)) + " total_trans", Err: err}
+		}
+	}
+	v, err = readStatsFile(filepath.Join(path, "trans_table"))
+	if err != nil {
+		return err
+	}
+	return parseTransTable(v, p)
+}
+
+// parseTransTable parses the contents of a trans_table file:
+//
+//	   From  :    To
+//	         :   2400000   1800000
+//	*2400000:         0         5
+//	 1800000:         4         0
+//
+// The first line is a header, the second line holds the frequencies being
+// transitioned to, and each remaining line holds the frequency being
+// transitioned from followed by the number of transitions to each frequency.
+// The current frequency's row may be marked with an '*'.
+func parseTransTable(v string, p *PolicyStats) error {
+	lines := strings.Split(v, "\n")
+	if len(lines) < 2 {
+		return nil
+	}
+	i := strings.IndexByte(lines[1], ':')
+	if i < 0 {
+		return nil
+	}
+	for _, fld := range strings.Fields(lines[1][i+1:]) {
+		n, err := strconv.ParseInt(fld, 10, 64)
+		if err != nil {
+			return &joe.ParseError{Info: "policy" + strconv.Itoa(int(p.ID)) + " trans_table", Err: err}
+		}
+		p.TransMHz = append(p.TransMHz, kHzToMHz(n))
+	}
+	for _, line := range lines[2:] {
+		i = strings.IndexByte(line, ':')
+		if i < 0 {
+			continue
+		}
+		fields := strings.Fields(line[i+1:])
+		row := make([]int64, len(fields))
+		for j, fld := range fields {
+			n, err := strconv.ParseInt(fld, 10, 64)
+			if err != nil {
+				return &joe.ParseError{Info: "policy" + strconv.Itoa(int(p.ID)) + " trans_table", Err: err}
+			}
+			row[j] = n
+		}
+		p.TransTable = append(p.TransTable, row)
+	}
+	return nil
+}
+
+// readStatsFile returns the contents of a cpufreq stats file. Like readFile,
+// a file that doesn't exist results in an empty string. The kernel returns
+// EFBIG when the trans_table would be larger than a page; that also results
+// in an empty string.
+func readStatsFile(path string) (string, error) {
+	v, err := readFile(path)
+	if err != nil {
+		if perr, ok := err.(*os.PathError); ok && perr.Err == syscall.EFBIG {
+			return "", nil
+		}
+		return "", err
+	}
+	return v, nil
+}
+
+var stdStats *StatsProfiler
+var stdStatsMu sync.Mutex
+
+// GetStats returns the cpufreq statistics using the package's global
+// StatsProfiler.
+func GetStats() (*Stats, error) {
+	stdStatsMu.Lock()
+	defer stdStatsMu.Unlock()
+	if stdStats == nil {
+		stdStats = NewStatsProfiler()
+	}
+	return stdStats.Get()
+}
+
+// Residency holds the distribution of the time spent at each frequency, by
+// policy, between two Stats snapshots. TimeDelta is in nanoseconds.
+type Residency struct {
+	Timestamp int64             `json:"timestamp"`
+	TimeDelta int64             `json:"time_delta"`
+	Policy    []PolicyResidency `json:"policy"`
+}
+
+// PolicyResidency holds the residency of a single policy. Transitions is the
+// number of frequency transitions that occurred during the interval.
+type PolicyResidency struct {
+	ID          int32            `json:"id"`
+	CPU         []int32          `json:"cpu"`
+	State       []StateResidency `json:"state"`
+	Transitions int64            `json:"transitions"`
+}
+
+// StateResidency is the time spent at a frequency, in MHz, during the
+// interval. Time is in USER_HZ units, which is usually 10ms, and Percent is
+// Time as a percentage of the time spent at all of the policy's frequencies.
+type StateResidency struct {
+	MHz     float32 `json:"mhz"`
+	Time    int64   `json:"time"`
+	Percent float32 `json:"percent"`
+}
+
+// CalculateResidency returns the residency of each policy between two Stats
+// snapshots. Policies and frequencies are matched by id and MHz,
+// respectively. A policy that doesn't exist in the prior snapshot is not
+// included. A frequency that doesn't exist in the prior snapshot, e.g. the
+// frequency table changed after a driver reload, has a delta of 0. If any of
+// a policy's counters is lower than its prior value, the policy's statistics
+// are assumed to have been reset and the current values are used as the
+// delta.
+func CalculateResidency(prior, cur *Stats) *Residency {
+	r := &Residency{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, Policy: make([]PolicyResidency, 0, len(cur.Policy))}
+	for i := 0; i < len(cur.Policy); i++ {
+		// policies are always in the same order; only search if they aren't.
+		if i < len(prior.Policy) && prior.Policy[i].ID == cur.Policy[i].ID {
+			r.Policy = append(r.Policy, calculatePolicyResidency(&prior.Policy[i], &cur.Policy[i]))
+			continue
+		}
+		for j := 0; j < len(prior.Policy); j++ {
+			if prior.Policy[j].ID == cur.Policy[i].ID {
+				r.Policy = append(r.Policy, calculatePolicyResidency(&prior.Policy[j], &cur.Policy[i]))
+				break
+			}
+		}
+	}
+	return r
+}
+
+// calculatePolicyResidency calculates the residency of a single policy.
+func calculatePolicyResidency(prior, cur *PolicyStats) PolicyResidency {
+	r := PolicyResidency{ID: cur.ID, CPU: cur.CPU, State: make([]StateResidency, len(cur.TimeInState))}
+	deltas := make([]int64, len(cur.TimeInState))
+	reset := cur.TotalTrans < prior.TotalTrans
+	for i := range cur.TimeInState {
+		d, ok := priorTime(prior, cur.TimeInState[i].MHz, i)
+		if !ok {
+			// the frequency is new, e.g. the frequency table changed; its time
+			// can't be attributed to the interval so its delta is 0.
+			continue
+		}
+		if cur.TimeInState[i].Time < d {
+			reset = true
+		}
+		deltas[i] = cur.TimeInState[i].Time - d
+	}
+	if reset {
+		// the statistics started over; everything since the reset is the delta.
+		for i := range cur.TimeInState {
+			deltas[i] = cur.TimeInState[i].Time
+		}
+		r.Transitions = cur.TotalTrans
+	} else {
+		r.Transitions = cur.TotalTrans - prior.TotalTrans
+	}
+	var total int64
+	for _, d := range deltas {
+		total += d
+	}
+	for i := range cur.TimeInState {
+		r.State[i] = StateResidency{MHz: cur.TimeInState[i].MHz, Time: deltas[i]}
+		if total > 0 {
+			r.State[i].Percent = float32(deltas[i]) / float32(total) * 100
+		}
+	}
+	return r
+}
+
+// priorTime returns the time in state of the frequency, mhz, from the prior
+// policy stats; i is the frequency's index in the current stats. If the
+// frequency isn't in the prior stats, false is returned.
+func priorTime(prior *PolicyStats, mhz float32, i int) (int64, bool) {
+	// frequencies are always in the same order; only search if they aren't.
+	if i < len(prior.TimeInState) && prior.TimeInState[i].MHz == mhz {
+		return prior.TimeInState[i].Time, true
+	}
+	for j := range prior.TimeInState {
+		if prior.TimeInState[j].MHz == mhz {
+			return prior.TimeInState[j].Time, true
+		}
+	}
+	return 0, false
+}
+
+// ResidencyProfiler is used to process the frequency residency; the last
+// Stats snapshot is kept so that the residency can be calculated.
+type ResidencyProfiler struct {
+	*StatsProfiler
+	prior *Stats
+}
+
+// Returns an initialized ResidencyProfiler; ready to use. The initial Stats
+// snapshot is taken during initialization.
+func NewResidencyProfiler() (prof *ResidencyProfiler, err error) {
+	p := NewStatsProfiler()
+	st, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &ResidencyProfiler{StatsProfiler: p, prior: st}, nil
+}
+
+// Get returns the frequency residency since the last time the
+// ResidencyProfiler was used. If this is the first use, the residency since
+// the ResidencyProfiler was created is returned.
+func (prof *ResidencyProfiler) Get() (r *Residency, err error) {
+	cur, err := prof.StatsProfiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	r = CalculateResidency(prof.prior, cur)
+	prof.prior = cur
+	return r, nil
+}
+
+// ResidencyTicker delivers the frequency residency of each policy at
+// intervals.
+type ResidencyTicker struct {
+	*joe.Ticker
+	Data chan *Residency
+	*ResidencyProfiler
+}
+
+// NewResidencyTicker returns a new ResidencyTicker containing a Data channel
+// that delivers the residency for each interval and an error channel that
+// delivers any errors encountered. Stop the ticker to signal the ticker to
+// stop running. Stopping the ticker does not close the Data channel; call
+// Close to close both the ticker and the data channel.
+func NewResidencyTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewResidencyProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := ResidencyTicker{Ticker: joe.NewTicker(d), Data: make(chan *Residency), ResidencyProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *ResidencyTicker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- r
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *ResidencyTicker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
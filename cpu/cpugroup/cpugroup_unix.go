@@ -0,0 +1,402 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpugroup aggregates the per cpu kernel activity, /proc/stat, by
+// physical package, socket, and by NUMA node. The sockets come from the cpu
+// topology, see cpux, and the nodes from the sysfs node tree, see node. The
+// values are aggregated since system boot; utilization is calculated from
+// the delta between two snapshots.
+//
+// The topology is read when the Profiler is created; if cpus are brought
+// online or offline, call UpdateTopology.
+package cpugroup
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	stats "github.com/c3sr/joefriday/cpu/cpustats"
+	"github.com/c3sr/joefriday/cpu/cpuutil"
+	"github.com/c3sr/joefriday/cpu/cpux"
+	"github.com/c3sr/joefriday/node"
+)
+
+// Topology holds the cpus of each socket and each NUMA node.
+type Topology struct {
+	Socket []Group `json:"socket"`
+	Node   []Group `json:"node"`
+}
+
+// Group is a group of cpus: a socket or a NUMA node.
+type Group struct {
+	ID  int32   `json:"id"`
+	CPU joe.Set `json:"cpu"`
+}
+
+// Stats holds the rolled-up cpu stats of each socket and each NUMA node.
+type Stats struct {
+	ClkTck    int16        `json:"clk_tck"`
+	Timestamp int64        `json:"timestamp"`
+	Socket    []GroupStats `json:"socket"`
+	Node      []GroupStats `json:"node"`
+}
+
+// GroupStats holds the rolled-up cpu stats of a group. Stats is the sum of
+// the counters of the group's cpus; its ID is the group's kind and id, e.g.
+// socket0 or node1. CPU holds the cpus whose counters were included; a cpu
+// that is in the group but isn't in /proc/stat, e.g. it's offline, isn't
+// included.
+type GroupStats struct {
+	ID    int32     `json:"id"`
+	CPU   joe.Set   `json:"cpu"`
+	Stats stats.CPU `json:"stats"`
+}
+
+// Profiler is used to process the per socket and per node cpu stats.
+type Profiler struct {
+	*stats.Profiler
+	CPUX     *cpux.Profiler
+	Node     *node.Profiler
+	topology *Topology
+}
+
+// Returns an initialized Profiler; ready to use. The topology is read during
+// initialization.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := stats.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	prof = &Profiler{Profiler: p, CPUX: cpux.NewProfiler(), Node: node.NewProfiler()}
+	err = prof.UpdateTopology()
+	if err != nil {
+		return nil, err
+	}
+	return prof, nil
+}
+
+// Topology returns the topology that is used to aggregate the cpu stats.
+func (prof *Profiler) Topology() *Topology {
+	return prof.topology
+}
+
+// UpdateTopology reads the cpu topology and the NUMA nodes. If the system
+// doesn't have a node tree, there won't be any nodes.
+func (prof *Profiler) UpdateTopology() error {
+	t, err := prof.CPUX.Topology()
+	if err != nil {
+		return err
+	}
+	topo := &Topology{Socket: make([]Group, 0, len(t.Socket))}
+	for _, s := range t.Socket {
+		topo.Socket = append(topo.Socket, Group{ID: s.ID, CPU: s.CPU.Clone()})
+	}
+	nodes, err := prof.Node.Get()
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if nodes != nil {
+		topo.Node = make([]Group, 0, len(nodes.Node))
+		for _, n := range nodes.Node {
+			topo.Node = append(topo.Node, Group{ID: n.ID, CPU: n.CPUList.Clone()})
+		}
+	}
+	prof.topology = topo
+	return nil
+}
+
+// SysFSSystemPath sets the sysfs system path of the cpux and node profilers
+// and updates the topology. This is for testing and should not be used
+// outside of tests.
+func (prof *Profiler) SysFSSystemPath(s string) error {
+	prof.CPUX.SysFSSystemPath(s)
+	prof.Node.SysFSSystemPath(s)
+	return prof.UpdateTopology()
+}
+
+// Get returns the current cpu stats of each socket and each NUMA node.
+func (prof *Profiler) Get() (*Stats, error) {
+	s, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return Aggregate(prof.topology, s), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the current cpu stats of each socket and each NUMA node using
+// the package's global Profiler.
+func Get() (s *Stats, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Aggregate rolls up the per cpu stats of s for each socket and each NUMA
+// node in the topology. The aggregate cpu entry of s isn't used.
+func Aggregate(t *Topology, s *stats.CPUStats) *Stats {
+	// index the cpu entries by cpu number.
+	var max int
+	for i := range s.CPU {
+		if x, ok := cpuNumber(s.CPU[i].ID); ok && x >= max {
+			max = x + 1
+		}
+	}
+	cpus := make([]*stats.CPU, max)
+	for i := range s.CPU {
+		if x, ok := cpuNumber(s.CPU[i].ID); ok {
+			cpus[x] = &s.CPU[i]
+		}
+	}
+	return &Stats{
+		ClkTck:    s.ClkTck,
+		Timestamp: s.Timestamp,
+		Socket:    aggregate("socket", t.Socket, cpus),
+		Node:      aggregate("node", t.Node, cpus),
+	}
+}
+
+// aggregate rolls up the cpus of each group; kind is used for the ID of the
+// group's stats.
+func aggregate(kind string, groups []Group, cpus []*stats.CPU) []GroupStats {
+	gs := make([]GroupStats, len(groups))
+	for i, g := range groups {
+		gs[i].ID = g.ID
+		gs[i].Stats.ID = kind + strconv.Itoa(int(g.ID))
+		g.CPU.ForEach(func(x int) {
+			if x >= len(cpus) || cpus[x] == nil {
+				return
+			}
+			gs[i].CPU.Add(x)
+			add(&gs[i].Stats, cpus[x])
+		})
+	}
+	return gs
+}
+
+// add adds the counters of c to sum.
+func add(sum, c *stats.CPU) {
+	sum.User += c.User
+	sum.Nice += c.Nice
+	sum.System += c.System
+	sum.Idle += c.Idle
+	sum.IOWait += c.IOWait
+	sum.IRQ += c.IRQ
+	sum.SoftIRQ += c.SoftIRQ
+	sum.Steal += c.Steal
+	sum.Quest += c.Quest
+	sum.QuestNice += c.QuestNice
+}
+
+// cpuNumber returns the cpu number of a cpu entry's ID, e.g. 3 for cpu3. A
+// false is returned for the aggregate, cpu, entry.
+func cpuNumber(id string) (int, bool) {
+	if !strings.HasPrefix(id, "cpu") {
+		return 0, false
+	}
+	x, err := strconv.Atoi(id[3:])
+	if err != nil || x < 0 {
+		return 0, false
+	}
+	return x, true
+}
+
+// Ticker delivers the cpu stats of each socket and each NUMA node at
+// intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *Stats
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *Stats), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- s
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
+
+// Util holds the utilization of each socket and each NUMA node between two
+// Stats snapshots. TimeDelta is in nanoseconds.
+type Util struct {
+	Timestamp int64       `json:"timestamp"`
+	TimeDelta int64       `json:"time_delta"`
+	Socket    []GroupUtil `json:"socket"`
+	Node      []GroupUtil `json:"node"`
+}
+
+// GroupUtil holds the utilization of a group. The utilization is of the cpus
+// that are in the group in both snapshots; see cpuutil.Util.
+type GroupUtil struct {
+	ID   int32        `json:"id"`
+	CPU  joe.Set      `json:"cpu"`
+	Util cpuutil.Util `json:"util"`
+}
+
+// CalculateUtil returns the utilization of each socket and each NUMA node
+// between two Stats snapshots. Groups are matched by ID; a group that
+// doesn't exist in the prior snapshot will have all of its values set to 0.
+// If the group's cpus changed between the snapshots, e.g. a cpu was brought
+// online, the utilization is skewed; UpdateTopology between snapshots should
+// be avoided.
+func CalculateUtil(prior, cur *Stats) *Util {
+	return &Util{
+		Timestamp: cur.Timestamp,
+		TimeDelta: cur.Timestamp - prior.Timestamp,
+		Socket:    calculateUtil(prior.Socket, cur.Socket),
+		Node:      calculateUtil(prior.Node, cur.Node),
+	}
+}
+
+// calculateUtil calculates the utilization of the groups. The calculation
+// is done by cpuutil, using the group's rolled-up stats as cpu entries.
+func calculateUtil(prior, cur []GroupStats) []GroupUtil {
+	p := &stats.CPUStats{CPU: make([]stats.CPU, len(prior))}
+	for i := range prior {
+		p.CPU[i] = prior[i].Stats
+	}
+	c := &stats.CPUStats{CPU: make([]stats.CPU, len(cur))}
+	for i := range cur {
+		c.CPU[i] = cur[i].Stats
+	}
+	u := cpuutil.Calculate(p, c)
+	gu := make([]GroupUtil, len(cur))
+	for i := range cur {
+		gu[i] = GroupUtil{ID: cur[i].ID, CPU: cur[i].CPU, Util: u.CPU[i]}
+	}
+	return gu
+}
+
+// UtilProfiler is used to process the utilization of each socket and each
+// NUMA node; the last Stats snapshot is kept so that the utilization can be
+// calculated.
+type UtilProfiler struct {
+	*Profiler
+	prior *Stats
+}
+
+// Returns an initialized UtilProfiler; ready to use. The initial Stats
+// snapshot is taken during initialization.
+func NewUtilProfiler() (prof *UtilProfiler, err error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	s, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &UtilProfiler{Profiler: p, prior: s}, nil
+}
+
+// Get returns the utilization of each socket and each NUMA node since the
+// last time the UtilProfiler was used. If this is the first use, the
+// utilization since the UtilProfiler was created is returned.
+func (prof *UtilProfiler) Get() (u *Util, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	u = CalculateUtil(prof.prior, cur)
+	prof.prior = cur
+	return u, nil
+}
+
+// UtilTicker delivers the utilization of each socket and each NUMA node at
+// intervals.
+type UtilTicker struct {
+	*joe.Ticker
+	Data chan *Util
+	*UtilProfiler
+}
+
+// NewUtilTicker returns a new UtilTicker containing a Data channel that
+// delivers the utilization for each interval and an error channel that
+// delivers any errors encountered. Stop the ticker to signal the ticker to
+// stop running. Stopping the ticker does not close the Data channel; call
+// Close to close both the ticker and the data channel.
+func NewUtilTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewUtilProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := UtilTicker{Ticker: joe.NewTicker(d), Data: make(chan *Util), UtilProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *UtilTicker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			u, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- u
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *UtilTicker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
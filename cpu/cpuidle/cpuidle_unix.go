@@ -0,0 +1,452 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpuidle provides information about the idle states, C-states, of
+// each online cpu: /sys/devices/system/cpu/cpuX/cpuidle/stateN. The usage and
+// time of each state are aggregated since system boot. The current cpuidle
+// driver and governor are also provided. If the system doesn't have cpuidle
+// information for a cpu, that cpu will not have any states.
+//
+// Residency, the percentage of time spent in each idle state over an
+// interval, is also provided.
+package cpuidle
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+)
+
+// CPUIdle holds the idle state information of the system's online cpus.
+type CPUIdle struct {
+	Timestamp int64  `json:"timestamp"`
+	Driver    string `json:"driver"`
+	Governor  string `json:"governor"`
+	CPU       []CPU  `json:"cpu"`
+}
+
+// CPU holds the idle states of a single cpu.
+type CPU struct {
+	ID    int32   `json:"id"`
+	State []State `json:"state"`
+}
+
+// State holds the information about a single idle state. Latency, the exit
+// latency, and Residency, the target residency, are in microseconds. Usage is
+// the number of times the state was entered and Time is the total time spent
+// in the state, in microseconds. Disable is whether or not the state has been
+// disabled.
+type State struct {
+	Index     int32  `json:"index"`
+	Name      string `json:"name"`
+	Desc      string `json:"desc"`
+	Latency   int64  `json:"latency"`
+	Residency int64  `json:"residency"`
+	Usage     int64  `json:"usage"`
+	Time      int64  `json:"time"`
+	Disable   bool   `json:"disable"`
+}
+
+// Profiler is used to process the system's cpuidle information.
+type Profiler struct {
+	sysFSSystemPath string
+	// path of the sysfs cpu tree; cached so it doesn't need to be constantly
+	// redone.
+	cpuPath string
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler) {
+	prof = &Profiler{}
+	prof.SysFSSystemPath(joe.SysFSSystem)
+	return prof
+}
+
+// Reset resources: this does nothing for this implemenation.
+func (prof *Profiler) Reset() error {
+	return nil
+}
+
+// Get returns the idle state information of the system's online cpus.
+func (prof *Profiler) Get() (*CPUIdle, error) {
+	var err error
+	idle := &CPUIdle{Timestamp: time.Now().UTC().UnixNano()}
+	idle.Driver, err = readFile(filepath.Join(prof.cpuPath, "cpuidle", "current_driver"))
+	if err != nil {
+		return nil, err
+	}
+	// current_governor is only available if cpuidle_sysfs_switch is set;
+	// otherwise current_governor_ro is.
+	idle.Governor, err = readFile(filepath.Join(prof.cpuPath, "cpuidle", "current_governor_ro"))
+	if err != nil {
+		return nil, err
+	}
+	if idle.Governor == "" {
+		idle.Governor, err = readFile(filepath.Join(prof.cpuPath, "cpuidle", "current_governor"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	v, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, "online"))
+	if err != nil {
+		return nil, err
+	}
+	online, err := joe.ParseSet(string(v))
+	if err != nil {
+		return nil, &joe.ParseError{Info: "online", Err: err}
+	}
+	idle.CPU = make([]CPU, 0, online.Count())
+	for _, x := range online.Members() {
+		cpu := CPU{ID: int32(x)}
+		cpu.State, err = prof.states(x)
+		if err != nil {
+			return nil, err
+		}
+		idle.CPU = append(idle.CPU, cpu)
+	}
+	return idle, nil
+}
+
+// states returns the idle states of cpuX, ordered by index.
+func (prof *Profiler) states(x int) ([]State, error) {
+	p := filepath.Join(prof.cpuPath, fmt.Sprintf("cpu%d", x), "cpuidle")
+	dirs, err := ioutil.ReadDir(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var states []State
+	for _, d := range dirs {
+		if !d.IsDir() || !strings.HasPrefix(d.Name(), "state") {
+			continue
+		}
+		n, err := strconv.Atoi(d.Name()[5:])
+		if err != nil {
+			continue // not a stateN dir
+		}
+		st, err := readState(filepath.Join(p, d.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("cpu%d %s: %s", x, d.Name(), err)
+		}
+		st.Index = int32(n)
+		states = append(states, st)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Index < states[j].Index })
+	return states, nil
+}
+
+// readState reads the idle state information in the stateN dir, p.
+func readState(p string) (st State, err error) {
+	st.Name, err = readFile(filepath.Join(p, "name"))
+	if err != nil {
+		return st, err
+	}
+	st.Desc, err = readFile(filepath.Join(p, "desc"))
+	if err != nil {
+		return st, err
+	}
+	st.Latency, err = readInt(filepath.Join(p, "latency"))
+	if err != nil {
+		return st, err
+	}
+	st.Residency, err = readInt(filepath.Join(p, "residency"))
+	if err != nil {
+		return st, err
+	}
+	st.Usage, err = readInt(filepath.Join(p, "usage"))
+	if err != nil {
+		return st, err
+	}
+	st.Time, err = readInt(filepath.Join(p, "time"))
+	if err != nil {
+		return st, err
+	}
+	disable, err := readInt(filepath.Join(p, "disable"))
+	if err != nil {
+		return st, err
+	}
+	st.Disable = disable != 0
+	return st, nil
+}
+
+// readFile returns the contents of a sysfs file with the trailing newline
+// removed. A file that doesn't exist is not an error and results in an empty
+// string.
+func readFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// readInt reads a sysfs file containing an integer. A file that doesn't exist
+// is not an error and results in 0.
+func readInt(path string) (int64, error) {
+	v, err := readFile(path)
+	if err != nil || v == "" {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, &joe.ParseError{Info: filepath.Base(path), Err: err}
+	}
+	return n, nil
+}
+
+// SysFSSystemPath enables overriding the default value. This is for testing
+// and should not be used outside of tests.
+func (prof *Profiler) SysFSSystemPath(s string) {
+	prof.sysFSSystemPath = s
+	prof.cpuPath = filepath.Join(s, "cpu")
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the idle state information using the package's global
+// Profiler.
+func Get() (*CPUIdle, error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std = NewProfiler()
+	}
+	return std.Get()
+}
+
+// Ticker delivers the system's idle state information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *CPUIdle
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *CPUIdle), Profiler: NewProfiler()}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			idle, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- idle
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
+
+// Residency holds the idle state residency of each cpu between two CPUIdle
+// snapshots. TimeDelta is in nanoseconds.
+type Residency struct {
+	Timestamp int64          `json:"timestamp"`
+	TimeDelta int64          `json:"time_delta"`
+	CPU       []CPUResidency `json:"cpu"`
+}
+
+// CPUResidency holds the idle state residency of a single cpu.
+type CPUResidency struct {
+	ID    int32            `json:"id"`
+	State []StateResidency `json:"state"`
+}
+
+// StateResidency holds the residency of a single idle state during the
+// interval. Usage is the number of times the state was entered and Time is
+// the time spent in the state, in microseconds. Percent is Time as a
+// percentage of the interval.
+type StateResidency struct {
+	Index   int32   `json:"index"`
+	Name    string  `json:"name"`
+	Usage   int64   `json:"usage"`
+	Time    int64   `json:"time"`
+	Percent float32 `json:"percent"`
+}
+
+// CalculateResidency returns the idle state residency of each cpu between two
+// CPUIdle snapshots. Cpus and states are matched by id and index,
+// respectively. A cpu that doesn't exist in the prior snapshot, e.g. a cpu
+// that was brought online between the two snapshots, is not included. A
+// state that doesn't exist in the prior snapshot has deltas of 0. If a
+// state's counters are lower than their prior values, the counters are
+// assumed to have been reset and the current values are used as the delta.
+func CalculateResidency(prior, cur *CPUIdle) *Residency {
+	r := &Residency{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, CPU: make([]CPUResidency, 0, len(cur.CPU))}
+	if r.TimeDelta <= 0 {
+		return r
+	}
+	for i := 0; i < len(cur.CPU); i++ {
+		// cpus are usually in the same order; only search if they aren't.
+		if i < len(prior.CPU) && prior.CPU[i].ID == cur.CPU[i].ID {
+			r.CPU = append(r.CPU, calculateCPUResidency(&prior.CPU[i], &cur.CPU[i], r.TimeDelta))
+			continue
+		}
+		for j := 0; j < len(prior.CPU); j++ {
+			if prior.CPU[j].ID == cur.CPU[i].ID {
+				r.CPU = append(r.CPU, calculateCPUResidency(&prior.CPU[j], &cur.CPU[i], r.TimeDelta))
+				break
+			}
+		}
+	}
+	return r
+}
+
+// calculateCPUResidency calculates the residency of a single cpu. The delta,
+// d, is in nanoseconds.
+func calculateCPUResidency(prior, cur *CPU, d int64) CPUResidency {
+	r := CPUResidency{ID: cur.ID, State: make([]StateResidency, len(cur.State))}
+	us := float32(d) / float32(time.Microsecond)
+	for i := range cur.State {
+		var (
+			p     State
+			found bool
+		)
+		// states are always in the same order; only search if they aren't.
+		if i < len(prior.State) && prior.State[i].Index == cur.State[i].Index {
+			p, found = prior.State[i], true
+		} else {
+			for j := range prior.State {
+				if prior.State[j].Index == cur.State[i].Index {
+					p, found = prior.State[j], true
+					break
+				}
+			}
+		}
+		if !found {
+			// the state is new, e.g. the cpuidle driver changed; its counters
+			// can't be attributed to the interval so its deltas are 0.
+			r.State[i] = StateResidency{Index: cur.State[i].Index, Name: cur.State[i].Name}
+			continue
+		}
+		if cur.State[i].Usage < p.Usage || cur.State[i].Time < p.Time {
+			// the counters started over; everything since the reset is the delta.
+			p = State{}
+		}
+		r.State[i] = StateResidency{
+			Index: cur.State[i].Index,
+			Name:  cur.State[i].Name,
+			Usage: cur.State[i].Usage - p.Usage,
+			Time:  cur.State[i].Time - p.Time,
+		}
+		r.State[i].Percent = float32(r.State[i].Time) / us * 100
+	}
+	return r
+}
+
+// ResidencyProfiler is used to process the idle state residency; the last
+// CPUIdle snapshot is kept so that the residency can be calculated.
+type ResidencyProfiler struct {
+	*Profiler
+	prior *CPUIdle
+}
+
+// Returns an initialized ResidencyProfiler; ready to use. The initial CPUIdle
+// snapshot is taken during initialization.
+func NewResidencyProfiler() (prof *ResidencyProfiler, err error) {
+	p := NewProfiler()
+	idle, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &ResidencyProfiler{Profiler: p, prior: idle}, nil
+}
+
+// Get returns the idle state residency since the last time the
+// ResidencyProfiler was used. If this is the first use, the residency since
+// the ResidencyProfiler was created is returned.
+func (prof *ResidencyProfiler) Get() (r *Residency, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	r = CalculateResidency(prof.prior, cur)
+	prof.prior = cur
+	return r, nil
+}
+
+// ResidencyTicker delivers the idle state residency of each cpu at intervals.
+type ResidencyTicker struct {
+	*joe.Ticker
+	Data chan *Residency
+	*ResidencyProfiler
+}
+
+// NewResidencyTicker returns a new ResidencyTicker containing a Data channel
+// that delivers the residency for each interval and an error channel that
+// delivers any errors encountered. Stop the ticker to signal the ticker to
+// stop running. Stopping the ticker does not close the Data channel; call
+// Close to close both the ticker and the data channel.
+func NewResidencyTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewResidencyProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := ResidencyTicker{Ticker: joe.NewTicker(d), Data: make(chan *Residency), ResidencyProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *ResidencyTicker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- r
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *ResidencyTicker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,220 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package cpuidle handles Flatbuffer based processing of the idle states,
+// C-states, of each online cpu: /sys/devices/system/cpu/cpuX/cpuidle/stateN.
+// Instead of returning a Go struct, it returns Flatbuffer serialized bytes. A
+// function to deserialize the Flatbuffer serialized bytes into a
+// cpuidle.CPUIdle struct is provided.
+//
+// Note: the package name is cpuidle and not the final element of the import
+// path (flat).
+package cpuidle
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	idle "github.com/c3sr/joefriday/cpu/cpuidle"
+	"github.com/c3sr/joefriday/cpu/cpuidle/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the cpuidle information as Flatbuffer
+// serialized bytes.
+type Profiler struct {
+	*idle.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized profiler that uses Flatbuffers.
+func NewProfiler() *Profiler {
+	return &Profiler{Profiler: idle.NewProfiler(), Builder: fb.NewBuilder(0)}
+}
+
+// Get returns the current idle state information as Flatbuffer serialized
+// bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	c, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(c), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the current idle state information as Flatbuffer serialized
+// bytes using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std = NewProfiler()
+	} else {
+		std.Builder.Reset()
+	}
+	return std.Get()
+}
+
+// Serialize cpuidle.CPUIdle using Flatbuffers.
+func (prof *Profiler) Serialize(c *idle.CPUIdle) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	cpusF := make([]fb.UOffsetT, len(c.CPU))
+	for i := range c.CPU {
+		cpusF[i] = prof.SerializeCPU(&c.CPU[i])
+	}
+	structs.CPUIdleStartCPUVector(prof.Builder, len(cpusF))
+	for i := len(cpusF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(cpusF[i])
+	}
+	cpusV := prof.Builder.EndVector(len(cpusF))
+	driver := prof.Builder.CreateString(c.Driver)
+	governor := prof.Builder.CreateString(c.Governor)
+	structs.CPUIdleStart(prof.Builder)
+	structs.CPUIdleAddTimestamp(prof.Builder, c.Timestamp)
+	structs.CPUIdleAddDriver(prof.Builder, driver)
+	structs.CPUIdleAddGovernor(prof.Builder, governor)
+	structs.CPUIdleAddCPU(prof.Builder, cpusV)
+	prof.Builder.Finish(structs.CPUIdleEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// SerializeCPU serializes a CPU using Flatbuffers and returns the resulting
+// UOffsetT.
+func (prof *Profiler) SerializeCPU(cpu *idle.CPU) fb.UOffsetT {
+	statesF := make([]fb.UOffsetT, len(cpu.State))
+	for i := range cpu.State {
+		statesF[i] = prof.SerializeState(&cpu.State[i])
+	}
+	structs.CPUStartStateVector(prof.Builder, len(statesF))
+	for i := len(statesF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(statesF[i])
+	}
+	statesV := prof.Builder.EndVector(len(statesF))
+	structs.CPUStart(prof.Builder)
+	structs.CPUAddID(prof.Builder, cpu.ID)
+	structs.CPUAddState(prof.Builder, statesV)
+	return structs.CPUEnd(prof.Builder)
+}
+
+// SerializeState serializes a State using Flatbuffers and returns the
+// resulting UOffsetT.
+func (prof *Profiler) SerializeState(st *idle.State) fb.UOffsetT {
+	name := prof.Builder.CreateString(st.Name)
+	desc := prof.Builder.CreateString(st.Desc)
+	structs.StateStart(prof.Builder)
+	structs.StateAddIndex(prof.Builder, st.Index)
+	structs.StateAddName(prof.Builder, name)
+	structs.StateAddDesc(prof.Builder, desc)
+	structs.StateAddLatency(prof.Builder, st.Latency)
+	structs.StateAddResidency(prof.Builder, st.Residency)
+	structs.StateAddUsage(prof.Builder, st.Usage)
+	structs.StateAddTime(prof.Builder, st.Time)
+	structs.StateAddDisable(prof.Builder, st.Disable)
+	return structs.StateEnd(prof.Builder)
+}
+
+// Serialize cpuidle.CPUIdle with Flatbuffers using the package's global
+// Profiler.
+func Serialize(c *idle.CPUIdle) []byte {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std = NewProfiler()
+	}
+	return std.Serialize(c)
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// cpuidle.CPUIdle.
+func Deserialize(p []byte) *idle.CPUIdle {
+	c := &idle.CPUIdle{}
+	cpuF := &structs.CPU{}
+	stateF := &structs.State{}
+	cF := structs.GetRootAsCPUIdle(p, 0)
+	c.Timestamp = cF.Timestamp()
+	c.Driver = string(cF.Driver())
+	c.Governor = string(cF.Governor())
+	c.CPU = make([]idle.CPU, cF.CPULength())
+	for i := range c.CPU {
+		if !cF.CPU(cpuF, i) {
+			continue
+		}
+		c.CPU[i].ID = cpuF.ID()
+		c.CPU[i].State = make([]idle.State, cpuF.StateLength())
+		for j := range c.CPU[i].State {
+			if !cpuF.State(stateF, j) {
+				continue
+			}
+			c.CPU[i].State[j] = idle.State{
+				Index:     stateF.Index(),
+				Name:      string(stateF.Name()),
+				Desc:      string(stateF.Desc()),
+				Latency:   stateF.Latency(),
+				Residency: stateF.Residency(),
+				Usage:     stateF.Usage(),
+				Time:      stateF.Time(),
+				Disable:   stateF.Disable(),
+			}
+		}
+	}
+	return c
+}
+
+// Ticker delivers the system's idle state information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: NewProfiler()}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package cpuidle handles JSON based processing of the idle states, C-states,
+// of each online cpu: /sys/devices/system/cpu/cpuX/cpuidle/stateN. Instead of
+// returning a Go struct, it returns JSON serialized bytes. A function to
+// deserialize the JSON serialized bytes into a cpuidle.CPUIdle struct is
+// provided.
+//
+// Note: the package name is cpuidle and not the final element of the import
+// path (json).
+package cpuidle
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	idle "github.com/c3sr/joefriday/cpu/cpuidle"
+)
+
+// Profiler is used to process the cpuidle information as JSON serialized
+// bytes.
+type Profiler struct {
+	*idle.Profiler
+}
+
+// Returns an initialized profiler that uses JSON.
+func NewProfiler() *Profiler {
+	return &Profiler{Profiler: idle.NewProfiler()}
+}
+
+// Get returns the current idle state information as JSON serialized bytes.
+func (prof *Profiler) Get() (p []byte, err error) {
+	c, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(c)
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent data race on checking/instantiation
+
+// Get returns the current idle state information as JSON serialized bytes
+// using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std = NewProfiler()
+	}
+	return std.Get()
+}
+
+// Serialize cpuidle.CPUIdle as JSON.
+func (prof *Profiler) Serialize(c *idle.CPUIdle) ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Serialize cpuidle.CPUIdle as JSON using package globals.
+func Serialize(c *idle.CPUIdle) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std = NewProfiler()
+	}
+	return std.Serialize(c)
+}
+
+// Marshal is an alias for Serialize.
+func (prof *Profiler) Marshal(c *idle.CPUIdle) ([]byte, error) {
+	return prof.Serialize(c)
+}
+
+// Marshal is an alias for Serialize using package globals.
+func Marshal(c *idle.CPUIdle) ([]byte, error) {
+	return Serialize(c)
+}
+
+// Deserialize takes some JSON serialized bytes and unmarshals them as
+// cpuidle.CPUIdle.
+func Deserialize(p []byte) (*idle.CPUIdle, error) {
+	c := &idle.CPUIdle{}
+	err := json.Unmarshal(p, c)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Unmarshal is an alias for Deserialize.
+func Unmarshal(p []byte) (*idle.CPUIdle, error) {
+	return Deserialize(p)
+}
+
+// Ticker delivers the system's idle state information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: NewProfiler()}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
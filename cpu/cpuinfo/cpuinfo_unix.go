@@ -13,6 +13,12 @@
 
 // Package cpuinfo handles processing of /proc/cpuinfo. The Info struct will
 // have one entry per processor.
+//
+// The x86, aarch64 (and 32 bit arm), ppc64le, and s390x layouts of
+// /proc/cpuinfo are supported. Keys that aren't recognized are kept in the
+// processor's Extra map. Keys that aren't part of a processor's information,
+// e.g. the system-wide information that ppc64le appends after the processors
+// or that s390x has before them, are kept in CPUInfo's Extra map.
 package cpuinfo
 
 import (
@@ -29,48 +35,78 @@ import (
 const procFile = "/proc/cpuinfo"
 
 // CPUInfo holds information about the system's cpus; CPU will have one entry
-// per processor.
+// per processor. Sockets is the number of unique physical ids; this will be 0
+// on architectures that don't report physical ids, e.g. arm. Extra holds the
+// system-wide key/value pairs, if any.
 type CPUInfo struct {
 	Timestamp int64
 	Sockets   int32
-	CPU       []CPU `json:"cpus"`
+	CPU       []CPU             `json:"cpus"`
+	Extra     map[string]string `json:"extra,omitempty"`
 }
 
-// CPU holds the /proc/cpuinfo for a single processor.
+// CPU holds the /proc/cpuinfo for a single processor. Which fields are
+// populated depends on the architecture. On arm, the CPU implementer,
+// architecture, variant, part, and revision are populated along with
+// BogoMIPS and Flags, which holds the Features. On ppc64le, ModelName holds
+// the cpu, CPUMHz the clock, along with Revision. On s390x, Version,
+// Identification, Machine, and CPUMHz (cpu MHz dynamic) are populated along
+// with the topology fields; VendorID, Flags (features), and BogoMIPS
+// (bogomips per cpu) are system-wide and are applied to every processor.
+//
+// Microarchitecture is decoded from the vendor, family, model, and stepping,
+// or, for arm, the implementer and part; see LookupMicroarchitecture.
+//
+// Any key that isn't recognized is kept in Extra.
+//
+// Processors with the same flags share the Flags slice, the same applies to
+// Bugs; they must not be modified. See Features for querying the flags.
 type CPU struct {
-	Processor       int32    `json:"processor"`
-	VendorID        string   `json:"vendor_id"`
-	CPUFamily       string   `json:"cpu_family"`
-	Model           string   `json:"model"`
-	ModelName       string   `json:"model_name"`
-	Stepping        string   `json:"stepping"`
-	Microcode       string   `json:"microcode"`
-	CPUMHz          float32  `json:"cpu_mhz"`
-	CacheSize       string   `json:"cache_size"`
-	PhysicalID      int32    `json:"physical_id"`
-	Siblings        int8     `json:"siblings"`
-	CoreID          int32    `json:"core_id"`
-	CPUCores        int32    `json:"cpu_cores"`
-	APICID          int32    `json:"apicid"`
-	InitialAPICID   int32    `json:"initial_apicid"`
-	FPU             string   `json:"fpu"`
-	FPUException    string   `json:"fpu_exception"`
-	CPUIDLevel      string   `json:"cpuid_level"`
-	WP              string   `json:"wp"`
-	Flags           []string `json:"flags"`
-	BogoMIPS        float32  `json:"bogomips"`
-	Bugs            []string `json:"bugs"`
-	CLFlushSize     uint16   `json:"clflush_size"`
-	CacheAlignment  uint16   `json:"cache_alignment"`
-	AddressSizes    []string `json:"address_sizes"`
-	PowerManagement []string `json:"power_management"`
-	TLBSize         string   `json:"tlb_size"`
+	Processor         int32             `json:"processor"`
+	VendorID          string            `json:"vendor_id"`
+	CPUFamily         string            `json:"cpu_family"`
+	Model             string            `json:"model"`
+	ModelName         string            `json:"model_name"`
+	Stepping          string            `json:"stepping"`
+	Microcode         string            `json:"microcode"`
+	CPUMHz            float32           `json:"cpu_mhz"`
+	CacheSize         string            `json:"cache_size"`
+	PhysicalID        int32             `json:"physical_id"`
+	Siblings          int8              `json:"siblings"`
+	CoreID            int32             `json:"core_id"`
+	CPUCores          int32             `json:"cpu_cores"`
+	APICID            int32             `json:"apicid"`
+	InitialAPICID     int32             `json:"initial_apicid"`
+	FPU               string            `json:"fpu"`
+	FPUException      string            `json:"fpu_exception"`
+	CPUIDLevel        string            `json:"cpuid_level"`
+	WP                string            `json:"wp"`
+	Flags             []string          `json:"flags"`
+	BogoMIPS          float32           `json:"bogomips"`
+	Bugs              []string          `json:"bugs"`
+	CLFlushSize       uint16            `json:"clflush_size"`
+	CacheAlignment    uint16            `json:"cache_alignment"`
+	AddressSizes      []string          `json:"address_sizes"`
+	PowerManagement   []string          `json:"power_management"`
+	TLBSize           string            `json:"tlb_size"`
+	CPUImplementer    string            `json:"cpu_implementer,omitempty"`
+	CPUArchitecture   string            `json:"cpu_architecture,omitempty"`
+	CPUVariant        string            `json:"cpu_variant,omitempty"`
+	CPUPart           string            `json:"cpu_part,omitempty"`
+	CPURevision       string            `json:"cpu_revision,omitempty"`
+	Revision          string            `json:"revision,omitempty"`
+	Version           string            `json:"version,omitempty"`
+	Identification    string            `json:"identification,omitempty"`
+	Machine           string            `json:"machine,omitempty"`
+	Microarchitecture Microarchitecture `json:"microarchitecture"`
+	Extra             map[string]string `json:"extra,omitempty"`
 }
 
 // Profiler is used to process the /proc/cpuinfo file.
 type Profiler struct {
 	joe.Procer
 	*joe.Buffer
+	lists map[string][]string // interned flag and bug lists
 }
 
 // Returns an initialized Profiler; ready to use.
@@ -91,19 +127,22 @@ func (prof *Profiler) Reset() error {
 // Get returns the current cpuinfo.
 func (prof *Profiler) Get() (inf *CPUInfo, err error) {
 	var (
-		cpuCnt, i, pos, nameLen int
-		n                       uint64
-		physIDs                 []int32 // tracks unique physical IDs encountered
-		pidFound                bool
-		v                       byte
-		tmp                     string
-		cpu                     CPU
+		i, pos, nameLen int
+		n               uint64
+		physIDs         []int32 // tracks unique physical IDs encountered
+		pidFound        bool
+		v               byte
+		key             string
+		inCPU           bool // whether the current line is part of a processor's info
+		cur             int  // index of the processor being processed
+		ok              bool
 	)
 	err = prof.Reset()
 	if err != nil {
 		return nil, err
 	}
 	inf = &CPUInfo{Timestamp: time.Now().UTC().UnixNano()}
+	prof.lists = map[string][]string{}
 	for {
 		prof.Line, err = prof.ReadSlice('\n')
 		if err != nil {
@@ -112,6 +151,11 @@ func (prof *Profiler) Get() (inf *CPUInfo, err error) {
 			}
 			return nil, &joe.ReadError{Err: err}
 		}
+		// a blank line ends a processor's info.
+		if len(joe.TrimTrailingSpaces(prof.Line)) == 0 {
+			inCPU = false
+			continue
+		}
 		prof.Val = prof.Val[:0]
 		// First grab the attribute name; everything up to the ':'.  The key may have
 		// spaces and has trailing spaces; that gets trimmed.
@@ -121,7 +165,6 @@ func (prof *Profiler) Get() (inf *CPUInfo, err error) {
 				pos = i + 1
 				break
 			}
-			//prof.Val = append(prof.Val, v)
 		}
 		prof.Val = joe.TrimTrailingSpaces(prof.Val[:])
 		nameLen = len(prof.Val)
@@ -131,213 +174,284 @@ func (prof *Profiler) Get() (inf *CPUInfo, err error) {
 		}
 		// if there's anything left, the value is everything else; trim spaces
 		if pos+1 < len(prof.Line) {
-			prof.Val = append(prof.Val, joe.TrimTrailingSpaces(prof.Line[pos+1:])...)
+			prof.Val = append(prof.Val, joe.TrimTrailingSpaces(joe.TrimLeadingSpaces(prof.Line[pos+1:]))...)
 		}
-		v = prof.Val[0]
-		if v == 'a' {
-			v = prof.Val[1]
-			if v == 'd' { // address sizes
-				cpu.AddressSizes = strings.Split(string(prof.Val[nameLen:]), ", ")
-				continue
-			}
-			if v == 'p' { // apicid
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.APICID = int32(n)
+		key = string(prof.Val[:nameLen])
+		// processor, or cpu number on s390x, starts information about a processor.
+		if key == "processor" || key == "cpu number" {
+			n, err = helpers.ParseUint(prof.Val[nameLen:])
+			if err != nil {
+				return nil, &joe.ParseError{Info: key, Err: err}
 			}
+			cur = inf.cpuIndex(int32(n))
+			inCPU = true
 			continue
 		}
-		if v == 'c' {
-			v = prof.Val[1]
-			if v == 'p' {
-				v = prof.Val[4]
-				if v == 'c' { // cpu cores
-					n, err = helpers.ParseUint(prof.Val[nameLen:])
-					if err != nil {
-						return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-					}
-					cpu.CPUCores = int32(n)
-					continue
-				}
-				if v == 'f' { // cpu family
-					cpu.CPUFamily = string(prof.Val[nameLen:])
-					continue
-				}
-				if v == 'M' { // cpu MHz
-					f, err := strconv.ParseFloat(string(prof.Val[nameLen:]), 32)
-					if err != nil {
-						return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-					}
-					cpu.CPUMHz = float32(f)
-					continue
-				}
-				if v == 'd' { // cpuid level
-					cpu.CPUIDLevel = string(prof.Val[nameLen:])
-				}
-				continue
-			}
-			v = prof.Val[5]
-			if v == '_' { // cache_alignment
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-
-				cpu.CacheAlignment = uint16(n)
-				continue
-			}
-			if v == ' ' { // cache size
-				cpu.CacheSize = string(prof.Val[nameLen:])
-				continue
-			}
-			if v == 's' { // clflush size
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.CLFlushSize = uint16(n)
-				continue
-			}
-			if v == 'i' { // core id
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.CoreID = int32(n)
+		// s390x has a "processor N" line per processor, before the processors'
+		// information.
+		if strings.HasPrefix(key, "processor ") {
+			n, err = helpers.ParseUint([]byte(key[10:]))
+			if err != nil {
+				return nil, &joe.ParseError{Info: key, Err: err}
 			}
+			inf.CPU[inf.cpuIndex(int32(n))].setS390Processor(string(prof.Val[nameLen:]))
 			continue
 		}
-		if v == 'f' {
-			v = prof.Val[1]
-			if v == 'l' { // flags
-				cpu.Flags = strings.Split(string(prof.Val[nameLen:]), " ")
-				continue
-			}
-			if v == 'p' {
-				if nameLen == 3 { // fpu
-					cpu.FPU = string(prof.Val[nameLen:])
-				} else { // fpu_exception
-					cpu.FPUException = string(prof.Val[nameLen:])
-				}
+		if !inCPU {
+			if inf.Extra == nil {
+				inf.Extra = map[string]string{}
 			}
+			inf.Extra[key] = string(prof.Val[nameLen:])
 			continue
 		}
-		if v == 'm' {
-			v = prof.Val[1]
-			if v == 'i' { // microcode
-				cpu.Microcode = string(prof.Val[nameLen:])
-				continue
-			}
-			if v == 'o' {
-				if nameLen == 5 { // model
-					cpu.Model = string(prof.Val[nameLen:])
-					continue
-				}
-				cpu.ModelName = string(prof.Val[nameLen:])
-			}
-			continue
+		ok, err = prof.set(&inf.CPU[cur], key, prof.Val[nameLen:])
+		if err != nil {
+			return nil, &joe.ParseError{Info: key, Err: err}
 		}
-		if v == 'p' {
-			v = prof.Val[1]
-			if v == 'h' { // physical id
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.PhysicalID = int32(n)
-				for i := range physIDs {
-					if physIDs[i] == cpu.PhysicalID {
-						pidFound = true
-						break
-					}
-				}
-				if pidFound {
-					pidFound = false // reset for next use
-				} else {
-					// physical id hasn't been encountered yet; add it
-					physIDs = append(physIDs, cpu.PhysicalID)
-				}
-				continue
-			}
-			if v == 'o' { // power management
-				tmp = string(prof.Val[nameLen:])
-				if tmp == "" {
-					continue
-				}
-				cpu.PowerManagement = strings.Split(tmp, " ")
-				continue
-			}
-			// processor starts information about a processor.
-			if v == 'r' { // processor
-				if cpuCnt > 0 {
-					inf.CPU = append(inf.CPU, cpu)
-				}
-				cpuCnt++
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu = CPU{Processor: int32(n)}
+		if !ok {
+			if inf.CPU[cur].Extra == nil {
+				inf.CPU[cur].Extra = map[string]string{}
 			}
+			inf.CPU[cur].Extra[key] = string(prof.Val[nameLen:])
 			continue
 		}
-		if v == 's' {
-			v = prof.Val[1]
-			if v == 'i' { // siblings
-				n, err = helpers.ParseUint(prof.Val[nameLen:])
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
+		if key == "physical id" {
+			for i := range physIDs {
+				if physIDs[i] == inf.CPU[cur].PhysicalID {
+					pidFound = true
+					break
 				}
-				cpu.Siblings = int8(n)
-				continue
 			}
-			if v == 't' { // stepping
-				cpu.Stepping = string(prof.Val[nameLen:])
+			if pidFound {
+				pidFound = false // reset for next use
+			} else {
+				// physical id hasn't been encountered yet; add it
+				physIDs = append(physIDs, inf.CPU[cur].PhysicalID)
 			}
-			continue
 		}
-		if v == 'b' {
-			if prof.Val[1] == 'o' { // bogomips
-				f, err := strconv.ParseFloat(string(prof.Val[nameLen:]), 32)
-				if err != nil {
-					return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-				}
-				cpu.BogoMIPS = float32(f)
-				continue
-			}
-			if prof.Val[1] == 'u' { // bugs
-				tmp = string(prof.Val[nameLen:])
-				if tmp != "" {
-					cpu.Bugs = strings.Split(tmp, " ")
-				}
-			}
+	}
+	err = inf.applySystemWide(prof.list)
+	if err != nil {
+		return nil, err
+	}
+	inf.Sockets = int32(len(physIDs))
+	for i := range inf.CPU {
+		// processors are usually the same model as the previous one.
+		if i > 0 && sameModel(&inf.CPU[i], &inf.CPU[i-1]) {
+			inf.CPU[i].Microarchitecture = inf.CPU[i-1].Microarchitecture
 			continue
 		}
-		if v == 'i' { // initial apicid
-			n, err = helpers.ParseUint(prof.Val[nameLen:])
-			if err != nil {
-				return nil, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
-			}
-			cpu.InitialAPICID = int32(n)
-			continue
+		inf.CPU[i].Microarchitecture, _ = LookupMicroarchitecture(&inf.CPU[i])
+	}
+	return inf, nil
+}
+
+// sameModel returns whether or not the processors have the same vendor,
+// family, model, stepping, implementer, and part.
+func sameModel(a, b *CPU) bool {
+	return a.VendorID == b.VendorID && a.CPUFamily == b.CPUFamily && a.Model == b.Model && a.Stepping == b.Stepping && a.CPUImplementer == b.CPUImplementer && a.CPUPart == b.CPUPart
+}
+
+// cpuIndex returns the index of the processor, x; if the processor doesn't
+// exist yet, it is added.
+func (inf *CPUInfo) cpuIndex(x int32) int {
+	// the processor being looked up is usually the last one added.
+	for i := len(inf.CPU) - 1; i >= 0; i-- {
+		if inf.CPU[i].Processor == x {
+			return i
 		}
-		if v == 'w' { // WP
-			cpu.WP = string(prof.Val[nameLen:])
-			continue
+	}
+	inf.CPU = append(inf.CPU, CPU{Processor: x})
+	return len(inf.CPU) - 1
+}
+
+// applySystemWide sets the fields of each processor that s390x only reports
+// system-wide; they are only set if the processor doesn't have a value. The
+// features are split into flags using list.
+func (inf *CPUInfo) applySystemWide(list func([]byte) []string) error {
+	if inf.Extra == nil {
+		return nil
+	}
+	var (
+		bogoMIPS float64
+		flags    []string
+		err      error
+	)
+	if s, ok := inf.Extra["features"]; ok {
+		flags = list([]byte(s))
+	}
+	if s, ok := inf.Extra["bogomips per cpu"]; ok {
+		bogoMIPS, err = strconv.ParseFloat(s, 32)
+		if err != nil {
+			return &joe.ParseError{Info: "bogomips per cpu", Err: err}
 		}
-		if v == 'v' { // vendor_id
-			cpu.VendorID = string(prof.Val[nameLen:])
+	}
+	for i := range inf.CPU {
+		if inf.CPU[i].VendorID == "" {
+			inf.CPU[i].VendorID = inf.Extra["vendor_id"]
+		}
+		if inf.CPU[i].Flags == nil {
+			inf.CPU[i].Flags = flags
 		}
-		if v == 'T' { //tlb size
-			cpu.TLBSize = string(prof.Val[nameLen:])
+		if inf.CPU[i].BogoMIPS == 0 {
+			inf.CPU[i].BogoMIPS = float32(bogoMIPS)
+		}
+	}
+	return nil
+}
+
+// set sets the cpu field that corresponds to the key using the value, v. If
+// the key isn't recognized, false is returned.
+func (prof *Profiler) set(cpu *CPU, key string, v []byte) (bool, error) {
+	var (
+		n   uint64
+		f   float64
+		err error
+	)
+	switch key {
+	// x86; s390x uses some of these for its per processor information.
+	case "vendor_id":
+		cpu.VendorID = string(v)
+	case "cpu family":
+		cpu.CPUFamily = string(v)
+	case "model":
+		cpu.Model = string(v)
+	case "model name":
+		cpu.ModelName = string(v)
+	case "stepping":
+		cpu.Stepping = string(v)
+	case "microcode":
+		cpu.Microcode = string(v)
+	case "cpu MHz", "cpu MHz dynamic":
+		f, err = strconv.ParseFloat(string(v), 32)
+		cpu.CPUMHz = float32(f)
+	case "cache size":
+		cpu.CacheSize = string(v)
+	case "physical id":
+		n, err = helpers.ParseUint(v)
+		cpu.PhysicalID = int32(n)
+	case "siblings":
+		n, err = helpers.ParseUint(v)
+		cpu.Siblings = int8(n)
+	case "core id":
+		n, err = helpers.ParseUint(v)
+		cpu.CoreID = int32(n)
+	case "cpu cores":
+		n, err = helpers.ParseUint(v)
+		cpu.CPUCores = int32(n)
+	case "apicid":
+		n, err = helpers.ParseUint(v)
+		cpu.APICID = int32(n)
+	case "initial apicid":
+		n, err = helpers.ParseUint(v)
+		cpu.InitialAPICID = int32(n)
+	case "fpu":
+		cpu.FPU = string(v)
+	case "fpu_exception":
+		cpu.FPUException = string(v)
+	case "cpuid level":
+		cpu.CPUIDLevel = string(v)
+	case "wp":
+		cpu.WP = string(v)
+	case "flags", "Features":
+		cpu.Flags = prof.list(v)
+	case "bugs":
+		cpu.Bugs = prof.list(v)
+	case "bogomips", "BogoMIPS":
+		f, err = strconv.ParseFloat(string(v), 32)
+		cpu.BogoMIPS = float32(f)
+	case "clflush size":
+		n, err = helpers.ParseUint(v)
+		cpu.CLFlushSize = uint16(n)
+	case "cache_alignment":
+		n, err = helpers.ParseUint(v)
+		cpu.CacheAlignment = uint16(n)
+	case "address sizes":
+		cpu.AddressSizes = strings.Split(string(v), ", ")
+	case "power management":
+		if len(v) > 0 {
+			cpu.PowerManagement = strings.Split(string(v), " ")
+		}
+	case "TLB size":
+		cpu.TLBSize = string(v)
+	// arm
+	case "CPU implementer":
+		cpu.CPUImplementer = string(v)
+	case "CPU architecture":
+		cpu.CPUArchitecture = string(v)
+	case "CPU variant":
+		cpu.CPUVariant = string(v)
+	case "CPU part":
+		cpu.CPUPart = string(v)
+	case "CPU revision":
+		cpu.CPURevision = string(v)
+	// ppc64le
+	case "cpu":
+		cpu.ModelName = string(v)
+	case "clock":
+		// e.g. 2166.000000MHz
+		f, err = strconv.ParseFloat(strings.TrimSuffix(string(v), "MHz"), 32)
+		cpu.CPUMHz = float32(f)
+	case "revision":
+		cpu.Revision = string(v)
+	// s390x
+	case "version":
+		cpu.Version = string(v)
+	case "identification":
+		cpu.Identification = string(v)
+	case "machine":
+		cpu.Machine = string(v)
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+// list splits a space separated list, e.g. flags, into its elements. The
+// lists are usually the same for every processor so each distinct list is
+// only split once; processors with the same list share the resulting slice,
+// which must not be modified. An empty list results in a nil slice.
+func (prof *Profiler) list(v []byte) []string {
+	if len(v) == 0 {
+		return nil
+	}
+	l, ok := prof.lists[string(v)]
+	if ok {
+		return l
+	}
+	if prof.lists == nil {
+		prof.lists = map[string][]string{}
+	}
+	l = strings.Fields(string(v))
+	prof.lists[string(v)] = l
+	return l
+}
+
+// setS390Processor sets the processor's version, identification, and machine
+// from the value of an s390x processor line, e.g. "version = FF,
+// identification = 0133E8,  machine = 2964". Any other key = value pairs are kept in Extra.
+func (cpu *CPU) setS390Processor(v string) {
+	for _, kv := range strings.Split(v, ",") {
+		i := strings.IndexByte(kv, '=')
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(kv[:i])
+		val := strings.TrimSpace(kv[i+1:])
+		switch key {
+		case "version":
+			cpu.Version = val
+		case "identification":
+			cpu.Identification = val
+		case "machine":
+			cpu.Machine = val
+		default:
+			if cpu.Extra == nil {
+				cpu.Extra = map[string]string{}
+			}
+			cpu.Extra[key] = val
 		}
 	}
-	// append the current processor informatin
-	inf.CPU = append(inf.CPU, cpu)
-	inf.Sockets = int32(len(physIDs))
-	return inf, nil
 }
 
 var std *Profiler
@@ -0,0 +1,345 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpuinfo
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/c3sr/joefriday"
+)
+
+// FlagTable interns flag names; each name is assigned a bit. FlagSets created
+// from the same table are compared using their bitsets.
+type FlagTable struct {
+	names []string
+	index map[string]int
+}
+
+// NewFlagTable returns an empty FlagTable.
+func NewFlagTable() *FlagTable {
+	return &FlagTable{index: map[string]int{}}
+}
+
+// Names returns the flag names in the table in bit order.
+func (t *FlagTable) Names() []string {
+	return t.names
+}
+
+// Set returns a FlagSet containing the provided flags; flags that aren't in
+// the table are added to it.
+func (t *FlagTable) Set(flags []string) FlagSet {
+	s := FlagSet{table: t}
+	for _, f := range flags {
+		s.bits.Add(t.intern(f))
+	}
+	return s
+}
+
+// intern returns the bit of the flag, adding the flag to the table if it isn't
+// already in it.
+func (t *FlagTable) intern(flag string) int {
+	i, ok := t.index[flag]
+	if ok {
+		return i
+	}
+	i = len(t.names)
+	t.names = append(t.names, flag)
+	t.index[flag] = i
+	return i
+}
+
+// FlagSet is a set of cpu flags, or bugs, stored as a bitset of the flags in
+// its FlagTable. A FlagSet is JSON encoded as a list of its flags.
+type FlagSet struct {
+	table *FlagTable
+	bits  joefriday.Set
+}
+
+// Has returns whether or not the flag, e.g. avx512f, is in the set.
+func (s FlagSet) Has(flag string) bool {
+	if s.table == nil {
+		return false
+	}
+	i, ok := s.table.index[flag]
+	return ok && s.bits.Has(i)
+}
+
+// HasAll returns whether or not all of the flags are in the set.
+func (s FlagSet) HasAll(flags ...string) bool {
+	for _, f := range flags {
+		if !s.Has(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of flags in the set.
+func (s FlagSet) Count() int {
+	return s.bits.Count()
+}
+
+// Flags returns the flags in the set in the order they were added to the
+// set's FlagTable, which is the order they are in /proc/cpuinfo.
+func (s FlagSet) Flags() []string {
+	flags := make([]string, 0, s.bits.Count())
+	s.bits.ForEach(func(i int) {
+		flags = append(flags, s.table.names[i])
+	})
+	return flags
+}
+
+// Equal returns whether or not s and o have the same flags. If both sets are
+// from the same FlagTable, only their bitsets are compared.
+func (s FlagSet) Equal(o FlagSet) bool {
+	if s.table == o.table {
+		return s.bits.Equal(o.bits)
+	}
+	if s.Count() != o.Count() {
+		return false
+	}
+	return o.HasAll(s.Flags()...)
+}
+
+// Difference returns the flags that are in s but not in o.
+func (s FlagSet) Difference(o FlagSet) []string {
+	var diff []string
+	if s.table == o.table {
+		s.bits.ForEach(func(i int) {
+			if !o.bits.Has(i) {
+				diff = append(diff, s.table.names[i])
+			}
+		})
+		return diff
+	}
+	for _, f := range s.Flags() {
+		if !o.Has(f) {
+			diff = append(diff, f)
+		}
+	}
+	return diff
+}
+
+// Intersection returns a new FlagSet containing the flags that are in both s
+// and o. The sets must be from the same FlagTable; if they aren't, o's flags
+// are added to s's table.
+func (s FlagSet) Intersection(o FlagSet) FlagSet {
+	if s.table != o.table {
+		if s.table == nil {
+			return s
+		}
+		o = s.table.Set(o.Flags())
+	}
+	return FlagSet{table: s.table, bits: s.bits.Intersection(o.bits)}
+}
+
+// Union returns a new FlagSet containing the flags of both s and o. The sets
+// must be from the same FlagTable; if they aren't, o's flags are added to s's
+// table.
+func (s FlagSet) Union(o FlagSet) FlagSet {
+	if s.table != o.table {
+		if s.table == nil {
+			return o
+		}
+		o = s.table.Set(o.Flags())
+	}
+	return FlagSet{table: s.table, bits: s.bits.Union(o.bits)}
+}
+
+// String returns the flags as a space separated list, which is how they are
+// in /proc/cpuinfo.
+func (s FlagSet) String() string {
+	return strings.Join(s.Flags(), " ")
+}
+
+// MarshalJSON encodes the set as a JSON list of its flags.
+func (s FlagSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Flags())
+}
+
+// UnmarshalJSON decodes a JSON list of flags into the set. The flags are
+// interned in the set's FlagTable; if the set doesn't have one, a new table is
+// used.
+func (s *FlagSet) UnmarshalJSON(p []byte) error {
+	var flags []string
+	err := json.Unmarshal(p, &flags)
+	if err != nil {
+		return err
+	}
+	t := s.table
+	if t == nil {
+		t = NewFlagTable()
+	}
+	*s = t.Set(flags)
+	return nil
+}
+
+// Features are the flags and bugs of the system's processors. Each distinct
+// combination of model name, flags, and bugs, i.e. each distinct cpu model,
+// is interned as a FeatureSet that is shared by the processors that have it;
+// CPU holds the index of each processor's FeatureSet, in CPUInfo.CPU order.
+//
+// Common holds the flags that every processor has and NonUniform holds the
+// flags that only some of the processors have. The same applies to
+// CommonBugs and NonUniformBugs.
+type Features struct {
+	FlagTable      *FlagTable   `json:"-"`
+	BugTable       *FlagTable   `json:"-"`
+	Set            []FeatureSet `json:"set"`
+	CPU            []int        `json:"cpu"`
+	Common         FlagSet      `json:"common"`
+	NonUniform     []string     `json:"non_uniform"`
+	CommonBugs     FlagSet      `json:"common_bugs"`
+	NonUniformBugs []string     `json:"non_uniform_bugs"`
+}
+
+// FeatureSet is the model name, flags, and bugs shared by a set of
+// processors.
+type FeatureSet struct {
+	ModelName string        `json:"model_name"`
+	Flags     FlagSet       `json:"flags"`
+	Bugs      FlagSet       `json:"bugs"`
+	CPU       joefriday.Set `json:"cpu"`
+	flags     []string      // the flags that the set was created from
+	bugs      []string      // the bugs that the set was created from
+}
+
+// Features returns the flags and bugs of the processors in inf.
+func (inf *CPUInfo) Features() *Features {
+	f := &Features{FlagTable: NewFlagTable(), BugTable: NewFlagTable(), CPU: make([]int, len(inf.CPU))}
+	for i := range inf.CPU {
+		f.CPU[i] = f.featureSet(&inf.CPU[i])
+	}
+	if len(f.Set) == 0 {
+		return f
+	}
+	f.Common, f.CommonBugs = f.Set[0].Flags, f.Set[0].Bugs
+	all, allBugs := f.Set[0].Flags, f.Set[0].Bugs
+	for _, s := range f.Set[1:] {
+		f.Common = f.Common.Intersection(s.Flags)
+		f.CommonBugs = f.CommonBugs.Intersection(s.Bugs)
+		all = all.Union(s.Flags)
+		allBugs = allBugs.Union(s.Bugs)
+	}
+	f.NonUniform = all.Difference(f.Common)
+	f.NonUniformBugs = allBugs.Difference(f.CommonBugs)
+	return f
+}
+
+// UnmarshalJSON decodes JSON encoded Features. The flags and bugs of the
+// decoded sets are interned in new FlagTable and BugTable tables so that
+// sets from the same Features can be compared using their bitsets.
+func (f *Features) UnmarshalJSON(p []byte) error {
+	type features Features // prevents recursion
+	var v features
+	err := json.Unmarshal(p, &v)
+	if err != nil {
+		return err
+	}
+	*f = Features(v)
+	f.FlagTable, f.BugTable = NewFlagTable(), NewFlagTable()
+	for i := range f.Set {
+		f.Set[i].flags = f.Set[i].Flags.Flags()
+		f.Set[i].bugs = f.Set[i].Bugs.Flags()
+		f.Set[i].Flags = f.FlagTable.Set(f.Set[i].flags)
+		f.Set[i].Bugs = f.BugTable.Set(f.Set[i].bugs)
+	}
+	f.Common = f.FlagTable.Set(f.Common.Flags())
+	f.CommonBugs = f.BugTable.Set(f.CommonBugs.Flags())
+	return nil
+}
+
+// featureSet returns the index of the FeatureSet for the cpu; if there isn't
+// one, it is added.
+func (f *Features) featureSet(cpu *CPU) int {
+	for i := range f.Set {
+		if f.Set[i].ModelName == cpu.ModelName && sameList(f.Set[i].flags, cpu.Flags) && sameList(f.Set[i].bugs, cpu.Bugs) {
+			f.Set[i].CPU.Add(int(cpu.Processor))
+			return i
+		}
+	}
+	f.Set = append(f.Set, FeatureSet{
+		ModelName: cpu.ModelName,
+		Flags:     f.FlagTable.Set(cpu.Flags),
+		Bugs:      f.BugTable.Set(cpu.Bugs),
+		CPU:       joefriday.NewSet(int(cpu.Processor)),
+		flags:     cpu.Flags,
+		bugs:      cpu.Bugs,
+	})
+	return len(f.Set) - 1
+}
+
+// Has returns whether or not every processor has the flag.
+func (f *Features) Has(flag string) bool {
+	return f.Common.Has(flag)
+}
+
+// HasAll returns whether or not every processor has all of the flags.
+func (f *Features) HasAll(flags ...string) bool {
+	return f.Common.HasAll(flags...)
+}
+
+// Uniform returns whether or not every processor has the same flags and bugs.
+func (f *Features) Uniform() bool {
+	return len(f.Set) <= 1
+}
+
+// CPUFlags returns the flags of processor x. A false is returned if there
+// isn't a processor x.
+func (f *Features) CPUFlags(x int32) (FlagSet, bool) {
+	for i := range f.Set {
+		if f.Set[i].CPU.Has(int(x)) {
+			return f.Set[i].Flags, true
+		}
+	}
+	return FlagSet{}, false
+}
+
+// CPUBugs returns the bugs of processor x. A false is returned if there isn't
+// a processor x.
+func (f *Features) CPUBugs(x int32) (FlagSet, bool) {
+	for i := range f.Set {
+		if f.Set[i].CPU.Has(int(x)) {
+			return f.Set[i].Bugs, true
+		}
+	}
+	return FlagSet{}, false
+}
+
+// Flags returns the flags that at least one processor has, sorted by name.
+func (f *Features) Flags() []string {
+	names := append([]string(nil), f.FlagTable.Names()...)
+	sort.Strings(names)
+	return names
+}
+
+// sameList returns whether or not two lists have the same elements in the
+// same order. Lists that share their backing array, e.g. interned flags, are
+// the same without comparing their elements.
+func sameList(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if len(a) == 0 || &a[0] == &b[0] {
+		return true
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -21,6 +21,7 @@
 package cpuinfo
 
 import (
+	"sort"
 	"sync"
 
 	fb "github.com/google/flatbuffers/go"
@@ -83,10 +84,12 @@ func (p *Profiler) Serialize(inf *info.CPUInfo) []byte {
 		p.Builder.PrependUOffsetT(uoffs[i])
 	}
 	cpusV := p.Builder.EndVector(len(uoffs))
+	extra := p.SerializeExtra(inf.Extra, structs.CPUInfoStartExtraVector)
 	structs.CPUInfoStart(p.Builder)
 	structs.CPUInfoAddTimestamp(p.Builder, inf.Timestamp)
 	structs.CPUInfoAddSockets(p.Builder, inf.Sockets)
 	structs.CPUInfoAddCPU(p.Builder, cpusV)
+	structs.CPUInfoAddExtra(p.Builder, extra)
 	p.Builder.Finish(structs.CPUInfoEnd(p.Builder))
 	b := p.Builder.Bytes[p.Builder.Head():]
 	// copy them (otherwise gets lost in reset)
@@ -149,6 +152,16 @@ func (p *Profiler) SerializeCPU(cpu *info.CPU) fb.UOffsetT {
 		p.Builder.PrependUOffsetT(uoffs[i])
 	}
 	powerManagement := p.Builder.EndVector(len(uoffs))
+	cpuImplementer := p.Builder.CreateString(cpu.CPUImplementer)
+	cpuArchitecture := p.Builder.CreateString(cpu.CPUArchitecture)
+	cpuVariant := p.Builder.CreateString(cpu.CPUVariant)
+	cpuPart := p.Builder.CreateString(cpu.CPUPart)
+	cpuRevision := p.Builder.CreateString(cpu.CPURevision)
+	revision := p.Builder.CreateString(cpu.Revision)
+	version := p.Builder.CreateString(cpu.Version)
+	identification := p.Builder.CreateString(cpu.Identification)
+	machine := p.Builder.CreateString(cpu.Machine)
+	extra := p.SerializeExtra(cpu.Extra, structs.CPUStartExtraVector)
 	structs.CPUStart(p.Builder)
 	structs.CPUAddProcessor(p.Builder, cpu.Processor)
 	structs.CPUAddVendorID(p.Builder, vendorID)
@@ -177,9 +190,44 @@ func (p *Profiler) SerializeCPU(cpu *info.CPU) fb.UOffsetT {
 	structs.CPUAddAddressSizes(p.Builder, addressSizes)
 	structs.CPUAddPowerManagement(p.Builder, powerManagement)
 	structs.CPUAddTLBSize(p.Builder, tlbSize)
+	structs.CPUAddCPUImplementer(p.Builder, cpuImplementer)
+	structs.CPUAddCPUArchitecture(p.Builder, cpuArchitecture)
+	structs.CPUAddCPUVariant(p.Builder, cpuVariant)
+	structs.CPUAddCPUPart(p.Builder, cpuPart)
+	structs.CPUAddCPURevision(p.Builder, cpuRevision)
+	structs.CPUAddRevision(p.Builder, revision)
+	structs.CPUAddVersion(p.Builder, version)
+	structs.CPUAddIdentification(p.Builder, identification)
+	structs.CPUAddMachine(p.Builder, machine)
+	structs.CPUAddExtra(p.Builder, extra)
 	return structs.CPUEnd(p.Builder)
 }
 
+// SerializeExtra serializes a map of extra key/value pairs as a vector of
+// KeyValue, in key order, using the provided function to start the vector;
+// the resulting UOffsetT is returned.
+func (p *Profiler) SerializeExtra(m map[string]string, start func(*fb.Builder, int) fb.UOffsetT) fb.UOffsetT {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	uoffs := make([]fb.UOffsetT, len(keys))
+	for i, k := range keys {
+		key := p.Builder.CreateString(k)
+		value := p.Builder.CreateString(m[k])
+		structs.KeyValueStart(p.Builder)
+		structs.KeyValueAddKey(p.Builder, key)
+		structs.KeyValueAddValue(p.Builder, value)
+		uoffs[i] = structs.KeyValueEnd(p.Builder)
+	}
+	start(p.Builder, len(uoffs))
+	for i := len(uoffs) - 1; i >= 0; i-- {
+		p.Builder.PrependUOffsetT(uoffs[i])
+	}
+	return p.Builder.EndVector(len(uoffs))
+}
+
 // Serialize cpuinfo.CPUInfo using the package global profiler.
 func Serialize(inf *info.CPUInfo) (p []byte, err error) {
 	stdMu.Lock()
@@ -203,6 +251,15 @@ func Deserialize(p []byte) *info.CPUInfo {
 	cpu := info.CPU{}
 	inf.Timestamp = fInf.Timestamp()
 	inf.Sockets = fInf.Sockets()
+	kv := &structs.KeyValue{}
+	if fInf.ExtraLength() > 0 {
+		inf.Extra = make(map[string]string, fInf.ExtraLength())
+		for i := 0; i < fInf.ExtraLength(); i++ {
+			if fInf.Extra(kv, i) {
+				inf.Extra[string(kv.Key())] = string(kv.Value())
+			}
+		}
+	}
 	for i := 0; i < l; i++ {
 		if !fInf.CPU(fCPU, i) {
 			continue
@@ -246,6 +303,27 @@ func Deserialize(p []byte) *info.CPUInfo {
 		for i := 0; i < len(cpu.Bugs); i++ {
 			cpu.Bugs[i] = string(fCPU.Bugs(i))
 		}
+		cpu.CPUImplementer = string(fCPU.CPUImplementer())
+		cpu.CPUArchitecture = string(fCPU.CPUArchitecture())
+		cpu.CPUVariant = string(fCPU.CPUVariant())
+		cpu.CPUPart = string(fCPU.CPUPart())
+		cpu.CPURevision = string(fCPU.CPURevision())
+		cpu.Revision = string(fCPU.Revision())
+		cpu.Version = string(fCPU.Version())
+		cpu.Identification = string(fCPU.Identification())
+		cpu.Machine = string(fCPU.Machine())
+		// the microarchitecture isn't serialized; it's decoded from the cpu.
+		cpu.Microarchitecture, _ = info.LookupMicroarchitecture(&cpu)
+		// the map can't be shared between cpus.
+		cpu.Extra = nil
+		if fCPU.ExtraLength() > 0 {
+			cpu.Extra = make(map[string]string, fCPU.ExtraLength())
+			for i := 0; i < fCPU.ExtraLength(); i++ {
+				if fCPU.Extra(kv, i) {
+					cpu.Extra[string(kv.Key())] = string(kv.Value())
+				}
+			}
+		}
 		inf.CPU = append(inf.CPU, cpu)
 	}
 	return inf
@@ -266,7 +266,102 @@ func (rcv *CPU) TLBSize() []byte {
 	return nil
 }
 
-func CPUStart(builder *flatbuffers.Builder) { builder.StartObject(27) }
+func (rcv *CPU) CPUImplementer() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(58))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) CPUArchitecture() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(60))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) CPUVariant() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(62))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) CPUPart() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(64))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) CPURevision() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(66))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) Revision() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(68))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) Version() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(70))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) Identification() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(72))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) Machine() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(74))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPU) Extra(obj *KeyValue, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(76))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+	if obj == nil {
+		obj = new(KeyValue)
+	}
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *CPU) ExtraLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(76))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func CPUStart(builder *flatbuffers.Builder) { builder.StartObject(37) }
 func CPUAddProcessor(builder *flatbuffers.Builder, Processor int32) { builder.PrependInt32Slot(0, Processor, 0) }
 func CPUAddVendorID(builder *flatbuffers.Builder, VendorID flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(VendorID), 0) }
 func CPUAddCPUFamily(builder *flatbuffers.Builder, CPUFamily flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(CPUFamily), 0) }
@@ -302,4 +397,16 @@ func CPUAddPowerManagement(builder *flatbuffers.Builder, PowerManagement flatbuf
 func CPUStartPowerManagementVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT { return builder.StartVector(4, numElems, 4)
 }
 func CPUAddTLBSize(builder *flatbuffers.Builder, TLBSize flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(26, flatbuffers.UOffsetT(TLBSize), 0) }
+func CPUAddCPUImplementer(builder *flatbuffers.Builder, CPUImplementer flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(27, flatbuffers.UOffsetT(CPUImplementer), 0) }
+func CPUAddCPUArchitecture(builder *flatbuffers.Builder, CPUArchitecture flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(28, flatbuffers.UOffsetT(CPUArchitecture), 0) }
+func CPUAddCPUVariant(builder *flatbuffers.Builder, CPUVariant flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(29, flatbuffers.UOffsetT(CPUVariant), 0) }
+func CPUAddCPUPart(builder *flatbuffers.Builder, CPUPart flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(30, flatbuffers.UOffsetT(CPUPart), 0) }
+func CPUAddCPURevision(builder *flatbuffers.Builder, CPURevision flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(31, flatbuffers.UOffsetT(CPURevision), 0) }
+func CPUAddRevision(builder *flatbuffers.Builder, Revision flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(32, flatbuffers.UOffsetT(Revision), 0) }
+func CPUAddVersion(builder *flatbuffers.Builder, Version flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(33, flatbuffers.UOffsetT(Version), 0) }
+func CPUAddIdentification(builder *flatbuffers.Builder, Identification flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(34, flatbuffers.UOffsetT(Identification), 0) }
+func CPUAddMachine(builder *flatbuffers.Builder, Machine flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(35, flatbuffers.UOffsetT(Machine), 0) }
+func CPUAddExtra(builder *flatbuffers.Builder, Extra flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(36, flatbuffers.UOffsetT(Extra), 0) }
+func CPUStartExtraVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT { return builder.StartVector(4, numElems, 4)
+}
 func CPUEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT { return builder.EndObject() }
@@ -0,0 +1,325 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpuinfo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Microarchitecture identifies a processor's microarchitecture. Name is the
+// microarchitecture, e.g. Skylake-SP, Zen 2, or Neoverse-N1. Generation is
+// the product generation, e.g. Xeon Scalable 1st gen or EPYC 7003 (Milan); for
+// arm it is the architecture version, e.g. Armv8.2-A. When products share a
+// model, Generation lists all of them, separated by ", ". Generation may be
+// empty. If the processor isn't in the decoding table, Name is empty.
+type Microarchitecture struct {
+	Vendor     string `json:"vendor"`
+	Name       string `json:"name"`
+	Generation string `json:"generation"`
+}
+
+// x86Microarch is an entry in the x86 decoding table. The entry matches a
+// processor's family and model, and, if it isn't 0, any stepping between
+// MinStepping and MaxStepping. If MinModel and MaxModel differ, the entry
+// matches the range of models.
+type x86Microarch struct {
+	Family      int
+	MinModel    int
+	MaxModel    int
+	MinStepping int
+	MaxStepping int
+	Name        string
+	Generation  string
+}
+
+// intelMicroarch is the decoding table for Intel; family 6 unless otherwise
+// specified. Entries with a stepping range must come before the entry for
+// the rest of the model's steppings.
+var intelMicroarch = []x86Microarch{
+	// server
+	{6, 0x1a, 0x1a, 0, 0, "Nehalem-EP", "Xeon 5500"},
+	{6, 0x2e, 0x2e, 0, 0, "Nehalem-EX", "Xeon 7500"},
+	{6, 0x2c, 0x2c, 0, 0, "Westmere-EP", "Xeon 5600"},
+	{6, 0x2f, 0x2f, 0, 0, "Westmere-EX", "Xeon E7"},
+	{6, 0x2d, 0x2d, 0, 0, "Sandy Bridge-EP", "Xeon E5 v1"},
+	{6, 0x3e, 0x3e, 0, 0, "Ivy Bridge-EP", "Xeon E5 v2"},
+	{6, 0x3f, 0x3f, 0, 0, "Haswell-EP", "Xeon E5 v3"},
+	{6, 0x4f, 0x4f, 0, 0, "Broadwell-EP", "Xeon E5 v4"},
+	{6, 0x56, 0x56, 0, 0, "Broadwell-DE", "Xeon D-1500"},
+	{6, 0x55, 0x55, 0, 4, "Skylake-SP", "Xeon Scalable 1st gen"},
+	{6, 0x55, 0x55, 5, 7, "Cascade Lake-SP", "Xeon Scalable 2nd gen"},
+	{6, 0x55, 0x55, 10, 11, "Cooper Lake", "Xeon Scalable 3rd gen"},
+	{6, 0x55, 0x55, 0, 0, "Skylake-SP", "Xeon Scalable"},
+	{6, 0x6a, 0x6a, 0, 0, "Ice Lake-SP", "Xeon Scalable 3rd gen"},
+	{6, 0x6c, 0x6c, 0, 0, "Ice Lake-D", "Xeon D-2700"},
+	{6, 0x8f, 0x8f, 0, 0, "Sapphire Rapids", "Xeon Scalable 4th gen"},
+	{6, 0xcf, 0xcf, 0, 0, "Emerald Rapids", "Xeon Scalable 5th gen"},
+	{6, 0xad, 0xad, 0, 0, "Granite Rapids", "Xeon 6"},
+	{6, 0xae, 0xae, 0, 0, "Granite Rapids-D", "Xeon 6"},
+	{6, 0xaf, 0xaf, 0, 0, "Sierra Forest", "Xeon 6"},
+	{6, 0xdd, 0xdd, 0, 0, "Clearwater Forest", "Xeon 6+"},
+	{6, 0x57, 0x57, 0, 0, "Knights Landing", "Xeon Phi x200"},
+	{6, 0x85, 0x85, 0, 0, "Knights Mill", "Xeon Phi 72x5"},
+	// client
+	{6, 0x1e, 0x1f, 0, 0, "Nehalem", "Core 1st gen"},
+	{6, 0x25, 0x25, 0, 0, "Westmere", "Core 1st gen"},
+	{6, 0x2a, 0x2a, 0, 0, "Sandy Bridge", "Core 2nd gen"},
+	{6, 0x3a, 0x3a, 0, 0, "Ivy Bridge", "Core 3rd gen"},
+	{6, 0x3c, 0x3c, 0, 0, "Haswell", "Core 4th gen"},
+	{6, 0x45, 0x46, 0, 0, "Haswell", "Core 4th gen"},
+	{6, 0x3d, 0x3d, 0, 0, "Broadwell", "Core 5th gen"},
+	{6, 0x47, 0x47, 0, 0, "Broadwell", "Core 5th gen"},
+	{6, 0x4e, 0x4e, 0, 0, "Skylake", "Core 6th gen"},
+	{6, 0x5e, 0x5e, 0, 0, "Skylake", "Core 6th gen"},
+	{6, 0x8e, 0x8e, 0, 9, "Kaby Lake", "Core 7th gen"},
+	{6, 0x8e, 0x8e, 10, 10, "Kaby Lake R", "Core 8th gen"},
+	{6, 0x8e, 0x8e, 11, 11, "Whiskey Lake", "Core 8th gen"},
+	{6, 0x8e, 0x8e, 12, 12, "Comet Lake", "Core 10th gen"},
+	{6, 0x8e, 0x8e, 0, 0, "Kaby Lake", "Core 7th gen"},
+	{6, 0x9e, 0x9e, 0, 9, "Kaby Lake", "Core 7th gen"},
+	{6, 0x9e, 0x9e, 10, 13, "Coffee Lake", "Core 8th gen"},
+	{6, 0x9e, 0x9e, 0, 0, "Coffee Lake", "Core 8th gen"},
+	{6, 0x66, 0x66, 0, 0, "Cannon Lake", "Core 8th gen"},
+	{6, 0xa5, 0xa6, 0, 0, "Comet Lake", "Core 10th gen"},
+	{6, 0x7d, 0x7e, 0, 0, "Ice Lake", "Core 10th gen"},
+	{6, 0x8c, 0x8d, 0, 0, "Tiger Lake", "Core 11th gen"},
+	{6, 0xa7, 0xa7, 0, 0, "Rocket Lake", "Core 11th gen"},
+	{6, 0x97, 0x97, 0, 0, "Alder Lake", "Core 12th gen"},
+	{6, 0x9a, 0x9a, 0, 0, "Alder Lake", "Core 12th gen"},
+	{6, 0xb7, 0xb7, 0, 0, "Raptor Lake", "Core 13th gen"},
+	{6, 0xba, 0xba, 0, 0, "Raptor Lake", "Core 13th gen"},
+	{6, 0xbf, 0xbf, 0, 0, "Raptor Lake", "Core 13th gen"},
+	{6, 0xaa, 0xac, 0, 0, "Meteor Lake", "Core Ultra Series 1"},
+	{6, 0xc5, 0xc6, 0, 0, "Arrow Lake", "Core Ultra Series 2"},
+	{6, 0xbd, 0xbd, 0, 0, "Lunar Lake", "Core Ultra Series 2"},
+	// atom
+	{6, 0x37, 0x37, 0, 0, "Silvermont", "Atom"},
+	{6, 0x4d, 0x4d, 0, 0, "Silvermont", "Atom C2000"},
+	{6, 0x4c, 0x4c, 0, 0, "Airmont", "Atom"},
+	{6, 0x5c, 0x5c, 0, 0, "Goldmont", "Atom"},
+	{6, 0x5f, 0x5f, 0, 0, "Goldmont", "Atom C3000"},
+	{6, 0x7a, 0x7a, 0, 0, "Goldmont Plus", "Atom"},
+	{6, 0x86, 0x86, 0, 0, "Tremont", "Atom P5900"},
+	{6, 0x96, 0x96, 0, 0, "Tremont", "Atom x6000E"},
+	{6, 0x9c, 0x9c, 0, 0, "Tremont", "Atom"},
+	{6, 0xbe, 0xbe, 0, 0, "Gracemont", "Core i3 N-series"},
+}
+
+// amdMicroarch is the decoding table for AMD. Entries for a specific model
+// must come before the entry for the family's range of models.
+var amdMicroarch = []x86Microarch{
+	{0xf, 0x00, 0xff, 0, 0, "K8", ""},
+	{0x10, 0x00, 0xff, 0, 0, "K10", ""},
+	{0x15, 0x00, 0x01, 0, 0, "Bulldozer", ""},
+	{0x15, 0x02, 0x02, 0, 0, "Piledriver", ""},
+	{0x15, 0x10, 0x1f, 0, 0, "Piledriver", ""},
+	{0x15, 0x30, 0x3f, 0, 0, "Steamroller", ""},
+	{0x15, 0x60, 0x7f, 0, 0, "Excavator", ""},
+	{0x16, 0x00, 0x0f, 0, 0, "Jaguar", ""},
+	{0x16, 0x30, 0x3f, 0, 0, "Puma", ""},
+	{0x17, 0x01, 0x01, 0, 0, "Zen", "Ryzen 1000 (Summit Ridge), Threadripper 1000 (Whitehaven), EPYC 7001 (Naples)"},
+	{0x17, 0x08, 0x08, 0, 0, "Zen+", "Ryzen 2000 (Pinnacle Ridge), Threadripper 2000 (Colfax)"},
+	{0x17, 0x11, 0x11, 0, 0, "Zen", "Ryzen 2000 (Raven Ridge)"},
+	{0x17, 0x18, 0x18, 0, 0, "Zen+", "Ryzen 3000 (Picasso)"},
+	{0x17, 0x31, 0x31, 0, 0, "Zen 2", "Threadripper 3000 (Castle Peak), EPYC 7002 (Rome)"},
+	{0x17, 0x60, 0x60, 0, 0, "Zen 2", "Ryzen 4000 (Renoir)"},
+	{0x17, 0x68, 0x68, 0, 0, "Zen 2", "Ryzen 5000 (Lucienne)"},
+	{0x17, 0x71, 0x71, 0, 0, "Zen 2", "Ryzen 3000 (Matisse)"},
+	{0x17, 0x90, 0x90, 0, 0, "Zen 2", "Van Gogh"},
+	{0x17, 0xa0, 0xa0, 0, 0, "Zen 2", "Ryzen 7020 (Mendocino)"},
+	{0x17, 0x00, 0x2f, 0, 0, "Zen", ""},
+	{0x17, 0x30, 0xff, 0, 0, "Zen 2", ""},
+	{0x19, 0x01, 0x01, 0, 0, "Zen 3", "EPYC 7003 (Milan)"},
+	{0x19, 0x08, 0x08, 0, 0, "Zen 3", "Threadripper 5000 (Chagall)"},
+	{0x19, 0x11, 0x11, 0, 0, "Zen 4", "EPYC 9004 (Genoa)"},
+	{0x19, 0x18, 0x18, 0, 0, "Zen 4", "Threadripper 7000 (Storm Peak)"},
+	{0x19, 0x21, 0x21, 0, 0, "Zen 3", "Ryzen 5000 (Vermeer)"},
+	{0x19, 0x44, 0x44, 0, 0, "Zen 3+", "Ryzen 6000 (Rembrandt)"},
+	{0x19, 0x50, 0x50, 0, 0, "Zen 3", "Ryzen 5000 (Cezanne), Ryzen 7030 (Barcelo)"},
+	{0x19, 0x61, 0x61, 0, 0, "Zen 4", "Ryzen 7000 (Raphael), Ryzen 7045 (Dragon Range)"},
+	{0x19, 0x74, 0x75, 0, 0, "Zen 4", "Ryzen 7040 (Phoenix), Ryzen 8040 (Hawk Point)"},
+	{0x19, 0xa0, 0xa0, 0, 0, "Zen 4c", "EPYC 9004 (Bergamo), EPYC 8004 (Siena)"},
+	{0x19, 0x00, 0x0f, 0, 0, "Zen 3", ""},
+	{0x19, 0x10, 0x1f, 0, 0, "Zen 4", ""},
+	{0x19, 0x20, 0x2f, 0, 0, "Zen 3", ""},
+	{0x19, 0x40, 0x4f, 0, 0, "Zen 3+", ""},
+	{0x19, 0x50, 0x5f, 0, 0, "Zen 3", ""},
+	{0x19, 0x60, 0x7f, 0, 0, "Zen 4", ""},
+	{0x19, 0xa0, 0xaf, 0, 0, "Zen 4c", ""},
+	{0x1a, 0x02, 0x02, 0, 0, "Zen 5", "EPYC 9005 (Turin)"},
+	{0x1a, 0x08, 0x08, 0, 0, "Zen 5", "Threadripper 9000 (Shimada Peak)"},
+	{0x1a, 0x11, 0x11, 0, 0, "Zen 5c", "EPYC 9005 (Turin Dense)"},
+	{0x1a, 0x24, 0x24, 0, 0, "Zen 5", "Ryzen AI 300 (Strix Point)"},
+	{0x1a, 0x44, 0x44, 0, 0, "Zen 5", "Ryzen 9000 (Granite Ridge), Ryzen 9000HX (Fire Range)"},
+	{0x1a, 0x60, 0x60, 0, 0, "Zen 5", "Ryzen AI 300 (Krackan Point)"},
+	{0x1a, 0x70, 0x70, 0, 0, "Zen 5", "Ryzen AI Max (Strix Halo)"},
+	{0x1a, 0x00, 0x0f, 0, 0, "Zen 5", ""},
+	{0x1a, 0x10, 0x1f, 0, 0, "Zen 5c", ""},
+	{0x1a, 0x20, 0x7f, 0, 0, "Zen 5", ""},
+}
+
+// hygonMicroarch is the decoding table for Hygon.
+var hygonMicroarch = []x86Microarch{
+	{0x18, 0x00, 0xff, 0, 0, "Dhyana", ""},
+}
+
+// armImplementer is an entry in the arm decoding table.
+type armImplementer struct {
+	Vendor string
+	Part   map[uint64]armPart
+}
+
+// armPart is the microarchitecture and architecture version of an arm part.
+type armPart struct {
+	Name         string
+	Architecture string
+}
+
+// armMicroarch is the decoding table for arm; it is keyed by the implementer.
+var armMicroarch = map[uint64]armImplementer{
+	0x41: {"ARM", map[uint64]armPart{
+		0xd03: {"Cortex-A53", "Armv8.0-A"},
+		0xd04: {"Cortex-A35", "Armv8.0-A"},
+		0xd05: {"Cortex-A55", "Armv8.2-A"},
+		0xd07: {"Cortex-A57", "Armv8.0-A"},
+		0xd08: {"Cortex-A72", "Armv8.0-A"},
+		0xd09: {"Cortex-A73", "Armv8.0-A"},
+		0xd0a: {"Cortex-A75", "Armv8.2-A"},
+		0xd0b: {"Cortex-A76", "Armv8.2-A"},
+		0xd0c: {"Neoverse-N1", "Armv8.2-A"},
+		0xd0d: {"Cortex-A77", "Armv8.2-A"},
+		0xd40: {"Neoverse-V1", "Armv8.4-A"},
+		0xd41: {"Cortex-A78", "Armv8.2-A"},
+		0xd44: {"Cortex-X1", "Armv8.2-A"},
+		0xd46: {"Cortex-A510", "Armv9.0-A"},
+		0xd47: {"Cortex-A710", "Armv9.0-A"},
+		0xd48: {"Cortex-X2", "Armv9.0-A"},
+		0xd49: {"Neoverse-N2", "Armv9.0-A"},
+		0xd4a: {"Neoverse-E1", "Armv8.2-A"},
+		0xd4d: {"Cortex-A715", "Armv9.0-A"},
+		0xd4e: {"Cortex-X3", "Armv9.0-A"},
+		0xd4f: {"Neoverse-V2", "Armv9.0-A"},
+		0xd80: {"Cortex-A520", "Armv9.2-A"},
+		0xd81: {"Cortex-A720", "Armv9.2-A"},
+		0xd82: {"Cortex-X4", "Armv9.2-A"},
+		0xd84: {"Neoverse-V3", "Armv9.2-A"},
+		0xd8e: {"Neoverse-N3", "Armv9.2-A"},
+	}},
+	0x43: {"Cavium", map[uint64]armPart{
+		0x0a1: {"ThunderX", "Armv8.0-A"},
+		0x0af: {"ThunderX2", "Armv8.1-A"},
+	}},
+	0x46: {"Fujitsu", map[uint64]armPart{
+		0x001: {"A64FX", "Armv8.2-A"},
+	}},
+	0x48: {"HiSilicon", map[uint64]armPart{
+		0xd01: {"TaiShan v110", "Armv8.2-A"},
+	}},
+	0x4e: {"NVIDIA", map[uint64]armPart{
+		0x003: {"Denver 2", "Armv8.0-A"},
+		0x004: {"Carmel", "Armv8.2-A"},
+	}},
+	0x50: {"Applied Micro", map[uint64]armPart{
+		0x000: {"X-Gene", "Armv8.0-A"},
+	}},
+	0x51: {"Qualcomm", map[uint64]armPart{
+		0xc00: {"Falkor", "Armv8.0-A"},
+		0x001: {"Oryon", "Armv8.7-A"},
+	}},
+	0x61: {"Apple", map[uint64]armPart{
+		0x022: {"Icestorm", "Armv8.5-A"},
+		0x023: {"Firestorm", "Armv8.5-A"},
+		0x032: {"Blizzard", "Armv8.6-A"},
+		0x033: {"Avalanche", "Armv8.6-A"},
+	}},
+	0xc0: {"Ampere", map[uint64]armPart{
+		0xac3: {"Ampere-1", "Armv8.6-A"},
+		0xac4: {"Ampere-1a", "Armv8.6-A"},
+	}},
+}
+
+// LookupMicroarchitecture returns the microarchitecture of the processor
+// using its vendor, family, model, and stepping, or, for arm, its implementer
+// and part. A false is returned if the processor isn't in the decoding table;
+// the Vendor will be set if it is known.
+func LookupMicroarchitecture(cpu *CPU) (Microarchitecture, bool) {
+	if cpu.CPUImplementer != "" {
+		return lookupARM(cpu.CPUImplementer, cpu.CPUPart)
+	}
+	switch cpu.VendorID {
+	case "GenuineIntel":
+		return lookupX86("Intel", intelMicroarch, cpu)
+	case "AuthenticAMD":
+		return lookupX86("AMD", amdMicroarch, cpu)
+	case "HygonGenuine":
+		return lookupX86("Hygon", hygonMicroarch, cpu)
+	}
+	return Microarchitecture{}, false
+}
+
+// lookupX86 looks up the processor's family, model, and stepping in the
+// table.
+func lookupX86(vendor string, table []x86Microarch, cpu *CPU) (Microarchitecture, bool) {
+	m := Microarchitecture{Vendor: vendor}
+	family, err := strconv.Atoi(cpu.CPUFamily)
+	if err != nil {
+		return m, false
+	}
+	model, err := strconv.Atoi(cpu.Model)
+	if err != nil {
+		return m, false
+	}
+	// a missing, or unparsable, stepping only matches entries without a
+	// stepping range.
+	stepping, err := strconv.Atoi(cpu.Stepping)
+	hasStepping := err == nil
+	for _, e := range table {
+		if e.Family != family || model < e.MinModel || model > e.MaxModel {
+			continue
+		}
+		if e.MaxStepping != 0 && (!hasStepping || stepping < e.MinStepping || stepping > e.MaxStepping) {
+			continue
+		}
+		m.Name = e.Name
+		m.Generation = e.Generation
+		return m, true
+	}
+	return m, false
+}
+
+// lookupARM looks up the implementer and part, both hex, e.g. 0x41, in the
+// arm table.
+func lookupARM(implementer, part string) (Microarchitecture, bool) {
+	i, err := strconv.ParseUint(strings.TrimPrefix(implementer, "0x"), 16, 32)
+	if err != nil {
+		return Microarchitecture{}, false
+	}
+	impl, ok := armMicroarch[i]
+	if !ok {
+		return Microarchitecture{}, false
+	}
+	m := Microarchitecture{Vendor: impl.Vendor}
+	p, err := strconv.ParseUint(strings.TrimPrefix(part, "0x"), 16, 32)
+	if err != nil {
+		return m, false
+	}
+	a, ok := impl.Part[p]
+	if !ok {
+		return m, false
+	}
+	m.Name = a.Name
+	m.Generation = a.Architecture
+	return m, true
+}
@@ -17,14 +17,18 @@
 package cpustats
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os/exec"
 	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/c3sr/joefriday/helpers"
 	joe "github.com/c3sr/joefriday"
@@ -32,13 +36,90 @@ import (
 
 const procFile = "/proc/stat"
 
+// auxvFile is the process's ELF auxiliary vector.
+const auxvFile = "/proc/self/auxv"
+
+// atClkTck is the auxiliary vector entry type for the frequency of times(),
+// i.e. CLK_TCK; atNull marks the end of the vector.
+const (
+	atNull   = 0
+	atClkTck = 17
+)
+
 var CLK_TCK int32    // the ticks per clock cycle
 var tckMu sync.Mutex //protects CLK_TCK
 
-// Set CLK_TCK.
+// Set CLK_TCK. CLK_TCK is read from the process's ELF auxiliary vector,
+// /proc/self/auxv. Only if that fails is `getconf CLK_TCK` used.
 func ClkTck() error {
 	tckMu.Lock()
 	defer tckMu.Unlock()
+	v, err := auxvClkTck(auxvFile)
+	if err == nil {
+		atomic.StoreInt32(&CLK_TCK, v)
+		return nil
+	}
+	gerr := getconfClkTck()
+	if gerr != nil {
+		return fmt.Errorf("%s; %s", err, gerr)
+	}
+	return nil
+}
+
+// SetClkTck overrides the CLK_TCK value; profilers created after this is
+// called will use v. This is for systems where CLK_TCK can't be determined
+// or is already known.
+func SetClkTck(v int32) {
+	tckMu.Lock()
+	defer tckMu.Unlock()
+	atomic.StoreInt32(&CLK_TCK, v)
+}
+
+// auxvClkTck returns the AT_CLKTCK value from the auxiliary vector file. The
+// vector is a list of type/value pairs, each of which is a native sized and
+// ordered word, that is terminated by an AT_NULL entry.
+func auxvClkTck(path string) (int32, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	wordSize := int(unsafe.Sizeof(uintptr(0)))
+	for i := 0; i+2*wordSize <= len(b); i += 2 * wordSize {
+		typ, val := auxvWord(b[i:], wordSize), auxvWord(b[i+wordSize:], wordSize)
+		if typ == atNull {
+			break
+		}
+		if typ == atClkTck {
+			if val == 0 {
+				return 0, fmt.Errorf("%s: AT_CLKTCK is 0", path)
+			}
+			return int32(val), nil
+		}
+	}
+	return 0, fmt.Errorf("%s: AT_CLKTCK not found", path)
+}
+
+// auxvWord returns the native ordered word at the start of p.
+func auxvWord(p []byte, wordSize int) uint64 {
+	if wordSize == 4 {
+		return uint64(nativeEndian.Uint32(p))
+	}
+	return nativeEndian.Uint64(p)
+}
+
+// nativeEndian is the system's byte order.
+var nativeEndian binary.ByteOrder = func() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}()
+
+// getconfClkTck sets CLK_TCK using the output of `getconf CLK_TCK`; this is
+// a last resort as it requires forking a process and getconf may not be
+// available, e.g. in scratch containers.
+func getconfClkTck() error {
 	var out bytes.Buffer
 	cmd := exec.Command("getconf", "CLK_TCK")
 	cmd.Stdout = &out
@@ -61,13 +142,23 @@ func ClkTck() error {
 // CPUStats holds the kernel activity information; /proc/stat. The first CPU
 // element's values are the aggregates of all other CPU elements. The stats are
 // aggregated from sytem boot.
+//
+// Intr is the total number of interrupts serviced, IRQ holds the number of
+// interrupts serviced for each numbered IRQ, with the IRQ number being the
+// index. ProcsRunning and ProcsBlocked are the number of processes currently
+// running and blocked waiting for I/O to complete, respectively.
 type CPUStats struct {
-	ClkTck    int16  `json:"clk_tck"`
-	Timestamp int64  `json:"timestamp"`
-	Ctxt      int64  `json:"ctxt"`
-	BTime     int64  `json:"btime"`
-	Processes int64  `json:"processes"`
-	CPU       []CPU `json:"cpu"`
+	ClkTck       int16   `json:"clk_tck"`
+	Timestamp    int64   `json:"timestamp"`
+	Ctxt         int64   `json:"ctxt"`
+	BTime        int64   `json:"btime"`
+	Processes    int64   `json:"processes"`
+	CPU          []CPU   `json:"cpu"`
+	Intr         int64   `json:"intr"`
+	IRQ          []int64 `json:"irq"`
+	ProcsRunning int64   `json:"procs_running"`
+	ProcsBlocked int64   `json:"procs_blocked"`
+	SoftIRQ      SoftIRQ `json:"softirq"`
 }
 
 // CPU holds the stats for a single CPU entry in the /proc/stat file.
@@ -85,11 +176,30 @@ type CPU struct {
 	QuestNice int64  `json:"quest_nice"`
 }
 
+// SoftIRQ holds the number of softirqs serviced; the total for all softirqs
+// and the count for each type of softirq.
+type SoftIRQ struct {
+	Total   int64 `json:"total"`
+	HI      int64 `json:"hi"`
+	Timer   int64 `json:"timer"`
+	NetTX   int64 `json:"net_tx"`
+	NetRX   int64 `json:"net_rx"`
+	Block   int64 `json:"block"`
+	IRQPoll int64 `json:"irq_poll"`
+	Tasklet int64 `json:"tasklet"`
+	Sched   int64 `json:"sched"`
+	HRTimer int64 `json:"hrtimer"`
+	RCU     int64 `json:"rcu"`
+}
+
 // Profiler is used to process the /proc/stats file.
 type Profiler struct {
 	joe.Procer
 	*joe.Buffer
 	ClkTck int16
+	// holds the values of the intr and softirq lines while they are being
+	// processed.
+	vals []int64
 }
 
 // Returns an initialized Profiler; ready to use.
@@ -136,7 +246,13 @@ func (prof *Profiler) Get() (stats *CPUStats, err error) {
 			if err == io.EOF {
 				break
 			}
-			return nil, &joe.ReadError{Err: err}
+			if err != bufio.ErrBufferFull {
+				return nil, &joe.ReadError{Err: err}
+			}
+			err = prof.LongLine(prof.Procer)
+			if err != nil {
+				return nil, err
+			}
 		}
 		prof.Val = prof.Val[:0]
 		// Get everything up to the first space, this is the key.  Not all keys are processed.
@@ -147,8 +263,27 @@ func (prof *Profiler) Get() (stats *CPUStats, err error) {
 				break
 			}
 		}
-		// skip the intr line
-		if prof.Val[0] == 'i' {
+		if len(prof.Val) == 0 {
+			continue
+		}
+		if prof.Val[0] == 'i' { // intr: the total followed by the count for each IRQ
+			prof.vals, err = parseCounts(prof.Line[pos:], prof.vals[:0])
+			if err != nil {
+				return stats, &joe.ParseError{Info: string(prof.Val[:]), Err: err}
+			}
+			if len(prof.vals) > 0 {
+				stats.Intr = prof.vals[0]
+				stats.IRQ = make([]int64, len(prof.vals)-1)
+				copy(stats.IRQ, prof.vals[1:])
+			}
+			continue
+		}
+		if prof.Val[0] == 's' { // softirq: the total followed by the count for each type
+			prof.vals, err = parseCounts(prof.Line[pos:], prof.vals[:0])
+			if err != nil {
+				return stats, &joe.ParseError{Info: string(prof.Val[:]), Err: err}
+			}
+			setSoftIRQ(&stats.SoftIRQ, prof.vals)
 			continue
 		}
 		if prof.Val[0] == 'c' {
@@ -236,19 +371,61 @@ func (prof *Profiler) Get() (stats *CPUStats, err error) {
 			stats.BTime = int64(n)
 			continue
 		}
-		if prof.Val[0] == 'p' && prof.Val[4] == 'e' { // processes info
+		if prof.Val[0] == 'p' {
 			// rest of the line is the data
 			n, err = helpers.ParseUint(prof.Line[pos : len(prof.Line)-1])
 			if err != nil {
 				return stats, &joe.ParseError{Info: string(prof.Val[:]), Err: err}
 			}
-			stats.Processes = int64(n)
+			if prof.Val[4] == 'e' { // processes info
+				stats.Processes = int64(n)
+				continue
+			}
+			if len(prof.Val) < 7 {
+				continue
+			}
+			if prof.Val[6] == 'r' { // procs_running
+				stats.ProcsRunning = int64(n)
+				continue
+			}
+			if prof.Val[6] == 'b' { // procs_blocked
+				stats.ProcsBlocked = int64(n)
+			}
 			continue
 		}
 	}
 	return stats, nil
 }
 
+// parseCounts parses the space separated values in p, up to the new line,
+// and appends them to v.
+func parseCounts(p []byte, v []int64) ([]int64, error) {
+	var start int
+	for i := 0; i < len(p); i++ {
+		if p[i] != 0x20 && p[i] != '\n' {
+			continue
+		}
+		if i > start {
+			n, err := helpers.ParseUint(p[start:i])
+			if err != nil {
+				return v, err
+			}
+			v = append(v, int64(n))
+		}
+		start = i + 1
+	}
+	return v, nil
+}
+
+// setSoftIRQ sets the softirq values, in the order they appear in the
+// softirq line.
+func setSoftIRQ(s *SoftIRQ, v []int64) {
+	fields := [...]*int64{&s.Total, &s.HI, &s.Timer, &s.NetTX, &s.NetRX, &s.Block, &s.IRQPoll, &s.Tasklet, &s.Sched, &s.HRTimer, &s.RCU}
+	for i := 0; i < len(v) && i < len(fields); i++ {
+		*fields[i] = v[i]
+	}
+}
+
 var std *Profiler
 var stdMu sync.Mutex
 
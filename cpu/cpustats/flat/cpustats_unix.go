@@ -107,6 +107,24 @@ func (prof *Profiler) Serialize(stts *stats.CPUStats) []byte {
 		prof.Builder.PrependUOffsetT(cpusF[i])
 	}
 	cpusV := prof.Builder.EndVector(len(cpusF))
+	structs.CPUStatsStartIRQVector(prof.Builder, len(stts.IRQ))
+	for i := len(stts.IRQ) - 1; i >= 0; i-- {
+		prof.Builder.PrependInt64(stts.IRQ[i])
+	}
+	irqV := prof.Builder.EndVector(len(stts.IRQ))
+	structs.SoftIRQStart(prof.Builder)
+	structs.SoftIRQAddTotal(prof.Builder, stts.SoftIRQ.Total)
+	structs.SoftIRQAddHI(prof.Builder, stts.SoftIRQ.HI)
+	structs.SoftIRQAddTimer(prof.Builder, stts.SoftIRQ.Timer)
+	structs.SoftIRQAddNetTX(prof.Builder, stts.SoftIRQ.NetTX)
+	structs.SoftIRQAddNetRX(prof.Builder, stts.SoftIRQ.NetRX)
+	structs.SoftIRQAddBlock(prof.Builder, stts.SoftIRQ.Block)
+	structs.SoftIRQAddIRQPoll(prof.Builder, stts.SoftIRQ.IRQPoll)
+	structs.SoftIRQAddTasklet(prof.Builder, stts.SoftIRQ.Tasklet)
+	structs.SoftIRQAddSched(prof.Builder, stts.SoftIRQ.Sched)
+	structs.SoftIRQAddHRTimer(prof.Builder, stts.SoftIRQ.HRTimer)
+	structs.SoftIRQAddRCU(prof.Builder, stts.SoftIRQ.RCU)
+	softIRQ := structs.SoftIRQEnd(prof.Builder)
 	structs.CPUStatsStart(prof.Builder)
 	structs.CPUStatsAddClkTck(prof.Builder, stts.ClkTck)
 	structs.CPUStatsAddTimestamp(prof.Builder, stts.Timestamp)
@@ -114,6 +132,11 @@ func (prof *Profiler) Serialize(stts *stats.CPUStats) []byte {
 	structs.CPUStatsAddBTime(prof.Builder, stts.BTime)
 	structs.CPUStatsAddProcesses(prof.Builder, stts.Processes)
 	structs.CPUStatsAddCPU(prof.Builder, cpusV)
+	structs.CPUStatsAddIntr(prof.Builder, stts.Intr)
+	structs.CPUStatsAddIRQ(prof.Builder, irqV)
+	structs.CPUStatsAddProcsRunning(prof.Builder, stts.ProcsRunning)
+	structs.CPUStatsAddProcsBlocked(prof.Builder, stts.ProcsBlocked)
+	structs.CPUStatsAddSoftIRQ(prof.Builder, softIRQ)
 	prof.Builder.Finish(structs.CPUStatsEnd(prof.Builder))
 	p := prof.Builder.Bytes[prof.Builder.Head():]
 	// copy them (otherwise gets lost in reset)
@@ -147,6 +170,27 @@ func Deserialize(p []byte) *stats.CPUStats {
 	statsS.Ctxt = statsF.Ctxt()
 	statsS.BTime = statsF.BTime()
 	statsS.Processes = statsF.Processes()
+	statsS.Intr = statsF.Intr()
+	statsS.ProcsRunning = statsF.ProcsRunning()
+	statsS.ProcsBlocked = statsF.ProcsBlocked()
+	statsS.IRQ = make([]int64, statsF.IRQLength())
+	for i := 0; i < len(statsS.IRQ); i++ {
+		statsS.IRQ[i] = statsF.IRQ(i)
+	}
+	softIRQF := statsF.SoftIRQ(nil)
+	if softIRQF != nil {
+		statsS.SoftIRQ.Total = softIRQF.Total()
+		statsS.SoftIRQ.HI = softIRQF.HI()
+		statsS.SoftIRQ.Timer = softIRQF.Timer()
+		statsS.SoftIRQ.NetTX = softIRQF.NetTX()
+		statsS.SoftIRQ.NetRX = softIRQF.NetRX()
+		statsS.SoftIRQ.Block = softIRQF.Block()
+		statsS.SoftIRQ.IRQPoll = softIRQF.IRQPoll()
+		statsS.SoftIRQ.Tasklet = softIRQF.Tasklet()
+		statsS.SoftIRQ.Sched = softIRQF.Sched()
+		statsS.SoftIRQ.HRTimer = softIRQF.HRTimer()
+		statsS.SoftIRQ.RCU = softIRQF.RCU()
+	}
 	len := statsF.CPULength()
 	statsS.CPU = make([]stats.CPU, len)
 	for i := 0; i < len; i++ {
@@ -0,0 +1,217 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpuutil handles the processing of CPU utilization information.
+// Utilization is calculated from the delta between two /proc/stat snapshots;
+// the values are the percentage of the elapsed CPU time spent in each state.
+// The first CPUUtil.CPU element is the utilization of all CPUs combined.
+package cpuutil
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	stats "github.com/c3sr/joefriday/cpu/cpustats"
+)
+
+// CPUUtil holds the utilization information of the CPUs. The TimeDelta field
+// holds the time elapsed, in nanoseconds, between the two snapshots used to
+// calculate the utilization.
+type CPUUtil struct {
+	Timestamp int64  `json:"timestamp"`
+	TimeDelta int64  `json:"time_delta"`
+	CPU       []Util `json:"cpu"`
+}
+
+// Util holds the utilization information for a single CPU entry, as
+// percentages. Usage is the percentage of time the CPU was not idle: idle and
+// iowait are both considered idle time. Guest and GuestNice time are also
+// accounted for in User and Nice, respectively.
+type Util struct {
+	ID        string  `json:"id"`
+	Usage     float32 `json:"usage"`
+	User      float32 `json:"user"`
+	Nice      float32 `json:"nice"`
+	System    float32 `json:"system"`
+	Idle      float32 `json:"idle"`
+	IOWait    float32 `json:"io_wait"`
+	IRQ       float32 `json:"irq"`
+	SoftIRQ   float32 `json:"soft_irq"`
+	Steal     float32 `json:"steal"`
+	Guest     float32 `json:"guest"`
+	GuestNice float32 `json:"guest_nice"`
+}
+
+// Profiler is used to process the CPU utilization information; the last
+// /proc/stat snapshot is kept so that utilization can be calculated.
+type Profiler struct {
+	*stats.Profiler
+	prior *stats.CPUStats
+}
+
+// Returns an initialized Profiler; ready to use. The initial /proc/stat
+// snapshot is taken during initialization.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := stats.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	s, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, prior: s}, nil
+}
+
+// Get returns the CPU utilization since the last time the Profiler was used.
+// If this is the first use, the utilization since the Profiler was created is
+// returned.
+func (prof *Profiler) Get() (u *CPUUtil, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	u = Calculate(prof.prior, cur)
+	prof.prior = cur
+	return u, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the CPU utilization using the package's global Profiler. The
+// utilization is calculated from the delta between this call and the prior
+// one; on the first call the global Profiler is created and the utilization
+// is calculated using the snapshot taken during its creation.
+func Get() (u *CPUUtil, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Calculate returns the utilization between two /proc/stat snapshots. The
+// CPU entries are matched by ID; an entry that doesn't exist in the prior
+// snapshot, e.g. a CPU that was brought online between the two snapshots,
+// will have all of its values set to 0. Any counter that is lower than its
+// prior value is treated as not having changed.
+func Calculate(prior, cur *stats.CPUStats) *CPUUtil {
+	u := &CPUUtil{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, CPU: make([]Util, len(cur.CPU))}
+	for i := 0; i < len(cur.CPU); i++ {
+		u.CPU[i].ID = cur.CPU[i].ID
+		// cpu entries are usually in the same order; only search if they aren't.
+		if i < len(prior.CPU) && prior.CPU[i].ID == cur.CPU[i].ID {
+			calculateUtil(&prior.CPU[i], &cur.CPU[i], &u.CPU[i])
+			continue
+		}
+		for j := 0; j < len(prior.CPU); j++ {
+			if prior.CPU[j].ID == cur.CPU[i].ID {
+				calculateUtil(&prior.CPU[j], &cur.CPU[i], &u.CPU[i])
+				break
+			}
+		}
+	}
+	return u
+}
+
+// calculateUtil calculates the utilization of a single CPU entry.
+func calculateUtil(prior, cur *stats.CPU, u *Util) {
+	user := delta(prior.User, cur.User)
+	nice := delta(prior.Nice, cur.Nice)
+	system := delta(prior.System, cur.System)
+	idle := delta(prior.Idle, cur.Idle)
+	ioWait := delta(prior.IOWait, cur.IOWait)
+	irq := delta(prior.IRQ, cur.IRQ)
+	softIRQ := delta(prior.SoftIRQ, cur.SoftIRQ)
+	steal := delta(prior.Steal, cur.Steal)
+	// guest time is already included in user and nice so it's not part of the
+	// total.
+	total := user + nice + system + idle + ioWait + irq + softIRQ + steal
+	if total == 0 {
+		return
+	}
+	t := float32(total)
+	u.User = float32(user) / t * 100
+	u.Nice = float32(nice) / t * 100
+	u.System = float32(system) / t * 100
+	u.Idle = float32(idle) / t * 100
+	u.IOWait = float32(ioWait) / t * 100
+	u.IRQ = float32(irq) / t * 100
+	u.SoftIRQ = float32(softIRQ) / t * 100
+	u.Steal = float32(steal) / t * 100
+	u.Guest = float32(delta(prior.Quest, cur.Quest)) / t * 100
+	u.GuestNice = float32(delta(prior.QuestNice, cur.QuestNice)) / t * 100
+	u.Usage = float32(total-idle-ioWait) / t * 100
+}
+
+// delta returns the difference between the current and prior values. If the
+// current value is lower than the prior value, e.g. the counter was reset, 0
+// is returned.
+func delta(prior, cur int64) int64 {
+	if cur < prior {
+		return 0
+	}
+	return cur - prior
+}
+
+// Ticker delivers the system's CPU utilization information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *CPUUtil
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *CPUUtil), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	// ticker
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			u, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- u
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,213 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpuutil handles Flatbuffer based processing of CPU utilization
+// information. Utilization is calculated from the delta between two
+// /proc/stat snapshots. The first CPUUtil.CPU element is the utilization of
+// all CPUs combined. Instead of returning a Go struct, it returns Flatbuffer
+// serialized bytes. A function to deserialize the Flatbuffer serialized bytes
+// into a cpuutil.CPUUtil struct is provided.
+//
+// Note: the package name is cpuutil and not the final element of the import
+// path (flat).
+package cpuutil
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	util "github.com/c3sr/joefriday/cpu/cpuutil"
+	"github.com/c3sr/joefriday/cpu/cpuutil/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the CPU utilization information as Flatbuffer
+// serialized bytes.
+type Profiler struct {
+	*util.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized profiler that uses Flatbuffers.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := util.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the CPU utilization since the last time the Profiler was used
+// as Flatbuffer serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	u, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(u), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the CPU utilization as Flatbuffer serialized bytes using the
+// package's global Profiler. On the first call the global Profiler is created
+// and the utilization is calculated using the snapshot taken during its
+// creation.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+
+	return std.Get()
+}
+
+// Serialize cpuutil.CPUUtil using Flatbuffers.
+func (prof *Profiler) Serialize(u *util.CPUUtil) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	utilF := make([]fb.UOffsetT, len(u.CPU))
+	ids := make([]fb.UOffsetT, len(u.CPU))
+	for i := 0; i < len(ids); i++ {
+		ids[i] = prof.Builder.CreateString(u.CPU[i].ID)
+	}
+	for i := 0; i < len(utilF); i++ {
+		structs.UtilStart(prof.Builder)
+		structs.UtilAddID(prof.Builder, ids[i])
+		structs.UtilAddUsage(prof.Builder, u.CPU[i].Usage)
+		structs.UtilAddUser(prof.Builder, u.CPU[i].User)
+		structs.UtilAddNice(prof.Builder, u.CPU[i].Nice)
+		structs.UtilAddSystem(prof.Builder, u.CPU[i].System)
+		structs.UtilAddIdle(prof.Builder, u.CPU[i].Idle)
+		structs.UtilAddIOWait(prof.Builder, u.CPU[i].IOWait)
+		structs.UtilAddIRQ(prof.Builder, u.CPU[i].IRQ)
+		structs.UtilAddSoftIRQ(prof.Builder, u.CPU[i].SoftIRQ)
+		structs.UtilAddSteal(prof.Builder, u.CPU[i].Steal)
+		structs.UtilAddGuest(prof.Builder, u.CPU[i].Guest)
+		structs.UtilAddGuestNice(prof.Builder, u.CPU[i].GuestNice)
+		utilF[i] = structs.UtilEnd(prof.Builder)
+	}
+	structs.CPUUtilStartCPUVector(prof.Builder, len(utilF))
+	for i := len(utilF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(utilF[i])
+	}
+	utilV := prof.Builder.EndVector(len(utilF))
+	structs.CPUUtilStart(prof.Builder)
+	structs.CPUUtilAddTimestamp(prof.Builder, u.Timestamp)
+	structs.CPUUtilAddTimeDelta(prof.Builder, u.TimeDelta)
+	structs.CPUUtilAddCPU(prof.Builder, utilV)
+	prof.Builder.Finish(structs.CPUUtilEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// Serialize cpuutil.CPUUtil with Flatbuffers using the package's global
+// Profiler.
+func Serialize(u *util.CPUUtil) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(u), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// cpuutil.CPUUtil.
+func Deserialize(p []byte) *util.CPUUtil {
+	u := &util.CPUUtil{}
+	utilF := &structs.Util{}
+	uF := structs.GetRootAsCPUUtil(p, 0)
+	u.Timestamp = uF.Timestamp()
+	u.TimeDelta = uF.TimeDelta()
+	len := uF.CPULength()
+	u.CPU = make([]util.Util, len)
+	for i := 0; i < len; i++ {
+		var tmp util.Util
+		if uF.CPU(utilF, i) {
+			tmp.ID = string(utilF.ID())
+			tmp.Usage = utilF.Usage()
+			tmp.User = utilF.User()
+			tmp.Nice = utilF.Nice()
+			tmp.System = utilF.System()
+			tmp.Idle = utilF.Idle()
+			tmp.IOWait = utilF.IOWait()
+			tmp.IRQ = utilF.IRQ()
+			tmp.SoftIRQ = utilF.SoftIRQ()
+			tmp.Steal = utilF.Steal()
+			tmp.Guest = utilF.Guest()
+			tmp.GuestNice = utilF.GuestNice()
+		}
+		u.CPU[i] = tmp
+	}
+	return u
+}
+
+// Ticker delivers the system's CPU utilization information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
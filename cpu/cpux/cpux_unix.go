@@ -18,6 +18,14 @@
 // exist on some systems. If the system doesn't have a particular path within
 // this path, the field's value will be the type's zero value.
 //
+// The system's cpu topology, Socket -> Die -> Core -> Thread, along with each
+// logical cpu's siblings and cache groups, is available using
+// Profiler.Topology.
+//
+// The state of the cpu vulnerabilities and their mitigations, the SMT
+// control, and the isolated and nohz_full cpus are included with the cpux
+// information.
+//
 // This package does not currently have a ticker implementation.
 package cpux
 
@@ -35,30 +43,52 @@ import (
 )
 
 const (
-	CPUFreq  = "cpufreq"
-	Offline  = "offline"
-	Online   = "online"
-	Possible = "possible"
-	Present  = "present"
+	CPUFreq         = "cpufreq"
+	Isolated        = "isolated"
+	NohzFull        = "nohz_full"
+	Offline         = "offline"
+	Online          = "online"
+	Possible        = "possible"
+	Present         = "present"
+	SMTDir          = "smt"
+	Vulnerabilities = "vulnerabilities"
 )
 
 type CPUs struct {
-	Sockets  int32  `json:"sockets"`
-	Possible string `json:"possible"`
-	Online   string `json:"online"`
-	Offline  string `json:"offline"`
-	Present  string `json:"present"`
-	CPU      []CPU  `json:"cpu"`
+	Sockets         int32           `json:"sockets"`
+	Possible        joefriday.Set   `json:"possible"`
+	Online          joefriday.Set   `json:"online"`
+	Offline         joefriday.Set   `json:"offline"`
+	Present         joefriday.Set   `json:"present"`
+	Isolated        joefriday.Set   `json:"isolated"`
+	NohzFull        joefriday.Set   `json:"nohz_full"`
+	SMT             SMT             `json:"smt"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	CPU             []CPU           `json:"cpu"`
 }
 
 type CPU struct {
-	PhysicalPackageID int32             `json:"physical_package_id"`
-	CoreID            int32             `json:"core_id"`
-	MHzMin            float32           `json:"mhz_min"`
-	MHzMax            float32           `json:"mhz_max"`
-	Cache             map[string]string `json:"cache:`
-	// a sorted list of caches so that the cache info can be pulled out in order.
-	CacheIDs []string `json:"cache_id"`
+	PhysicalPackageID int32   `json:"physical_package_id"`
+	CoreID            int32   `json:"core_id"`
+	MHzMin            float32 `json:"mhz_min"`
+	MHzMax            float32 `json:"mhz_max"`
+	Cache             []Cache `json:"cache"`
+}
+
+// Cache describes a cpu cache. ID is the cache's name, as used by lscpu, e.g.
+// L1d cache, L1i cache, L2 cache. Type is Data, Instruction, or Unified. Size
+// is in bytes and LineSize, the coherency line size, is also in bytes.
+// SharedCPUList is the set of cpus that share the cache. Values that the
+// system doesn't provide are the type's zero value.
+type Cache struct {
+	ID            string        `json:"id"`
+	Level         int32         `json:"level"`
+	Type          string        `json:"type"`
+	Size          int64         `json:"size"`
+	Ways          int32         `json:"ways"`
+	LineSize      int32         `json:"line_size"`
+	Sets          int32         `json:"sets"`
+	SharedCPUList joefriday.Set `json:"shared_cpu_list"`
 }
 
 // GetCPU returns the cpu information for the provided physical_package_id
@@ -73,6 +103,53 @@ func (c *CPUs) GetCPU(pID, coreID int32) (cpu CPU, found bool) {
 	return CPU{}, false
 }
 
+// Caches returns the system's caches. Caches that are shared by multiple cpus
+// are only included once. The caches are ordered by level, type, and the
+// lowest cpu sharing the cache.
+func (c *CPUs) Caches() []Cache {
+	var caches [][]Cache
+	for i := range c.CPU {
+		caches = append(caches, c.CPU[i].Cache)
+	}
+	return uniqueCaches(caches...)
+}
+
+// uniqueCaches returns the unique caches within the lists of caches. Caches
+// are the same if they have the same level, type, and shared cpus.
+func uniqueCaches(lists ...[]Cache) []Cache {
+	var caches []Cache
+	for _, l := range lists {
+	next:
+		for _, c := range l {
+			for _, v := range caches {
+				if v.Level == c.Level && v.Type == c.Type && v.SharedCPUList.Equal(c.SharedCPUList) {
+					continue next
+				}
+			}
+			caches = append(caches, c)
+		}
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		if caches[i].Level != caches[j].Level {
+			return caches[i].Level < caches[j].Level
+		}
+		if caches[i].Type != caches[j].Type {
+			return caches[i].Type < caches[j].Type
+		}
+		return lowest(caches[i].SharedCPUList) < lowest(caches[j].SharedCPUList)
+	})
+	return caches
+}
+
+// lowest returns the lowest member of the set; -1 if the set is empty.
+func lowest(s joefriday.Set) int {
+	m := s.Members()
+	if len(m) == 0 {
+		return -1
+	}
+	return m[0]
+}
+
 // Profiler is used to process the system's cpuX information.
 type Profiler struct {
 	// this is an exported fied for testing purposes. It should not be set in
@@ -129,7 +206,7 @@ func (prof *Profiler) Get() (*CPUs, error) {
 		if err != nil {
 			return nil, err
 		}
-		err := prof.cache(x, &cpu)
+		cpu.Cache, err = prof.caches(x)
 		if err != nil {
 			return nil, err
 		}
@@ -166,6 +243,26 @@ func (prof *Profiler) Get() (*CPUs, error) {
 		return nil, err
 	}
 
+	cpus.Isolated, err = prof.Isolated()
+	if err != nil {
+		return nil, err
+	}
+
+	cpus.NohzFull, err = prof.NohzFull()
+	if err != nil {
+		return nil, err
+	}
+
+	cpus.SMT, err = prof.SMT()
+	if err != nil {
+		return nil, err
+	}
+
+	cpus.Vulnerabilities, err = prof.Vulnerabilities()
+	if err != nil {
+		return nil, err
+	}
+
 	return cpus, nil
 }
 
@@ -267,86 +364,157 @@ func (prof *Profiler) cpuMHzMax(x int) (float32, error) {
 	return float32(m), nil
 }
 
-// Get the cache info for the given cpuX entry
-func (prof *Profiler) cache(x int, cpu *CPU) error {
-	cpu.Cache = map[string]string{}
+// caches gets the cache info for the given cpuX entry. The caches are sorted
+// by level and then type. If the cpu doesn't have any cache information, e.g.
+// the cache dir doesn't exist, no caches are returned.
+func (prof *Profiler) caches(x int) ([]Cache, error) {
 	//go through all the entries in cpuX/cache
 	p := prof.cachePath(x)
 	dirs, err := ioutil.ReadDir(p)
 	if err != nil {
-		return err
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	var cacheID string
-	// all the entries should be dirs with their contents holding the cache info
+	var caches []Cache
+	// the indexN entries should be dirs with their contents holding the cache
+	// info
 	for _, d := range dirs {
-		if !d.IsDir() {
+		if !d.IsDir() || !strings.HasPrefix(d.Name(), "index") {
 			continue // this shouldn't happen but if it does we just skip the entry
 		}
-		// cache level
-		l, err := ioutil.ReadFile(filepath.Join(p, d.Name(), "level"))
+		c, err := prof.cache(filepath.Join(p, d.Name()))
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("cpu%d cache %s: %s", x, d.Name(), err)
 		}
-
-		t, err := ioutil.ReadFile(filepath.Join(p, d.Name(), "type"))
-		if err != nil {
-			return err
+		caches = append(caches, c)
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		if caches[i].Level != caches[j].Level {
+			return caches[i].Level < caches[j].Level
 		}
+		return caches[i].Type < caches[j].Type
+	})
+	return caches, nil
+}
 
-		// cache type: unified entries aren't decorated, otherwise the first letter is used
-		// like what lscpu does.
-		if t[0] != 'U' && t[0] != 'u' {
-			cacheID = fmt.Sprintf("L%s%s cache", string(l[:len(l)-1]), strings.ToLower(string(t[0])))
-		} else {
-			cacheID = fmt.Sprintf("L%s cache", string(l[:len(l)-1]))
-		}
+// cache gets the cache info in the cache indexN dir, p.
+func (prof *Profiler) cache(p string) (c Cache, err error) {
+	c.Level, err = readID(filepath.Join(p, "level"), 0)
+	if err != nil {
+		return c, fmt.Errorf("level: %s", err)
+	}
+	t, err := ioutil.ReadFile(filepath.Join(p, "type"))
+	if err != nil {
+		return c, err
+	}
+	c.Type = strings.TrimSpace(string(t))
+	// cache id: unified entries aren't decorated, otherwise the first letter
+	// of the type is used like what lscpu does.
+	if c.Type != "" && c.Type[0] != 'U' && c.Type[0] != 'u' {
+		c.ID = fmt.Sprintf("L%d%s cache", c.Level, strings.ToLower(c.Type[:1]))
+	} else {
+		c.ID = fmt.Sprintf("L%d cache", c.Level)
+	}
+	c.Size, err = readSize(filepath.Join(p, "size"))
+	if err != nil {
+		return c, fmt.Errorf("size: %s", err)
+	}
+	c.Ways, err = readID(filepath.Join(p, "ways_of_associativity"), 0)
+	if err != nil {
+		return c, fmt.Errorf("ways_of_associativity: %s", err)
+	}
+	c.LineSize, err = readID(filepath.Join(p, "coherency_line_size"), 0)
+	if err != nil {
+		return c, fmt.Errorf("coherency_line_size: %s", err)
+	}
+	c.Sets, err = readID(filepath.Join(p, "number_of_sets"), 0)
+	if err != nil {
+		return c, fmt.Errorf("number_of_sets: %s", err)
+	}
+	c.SharedCPUList, err = readList(filepath.Join(p, "shared_cpu_list"))
+	if err != nil {
+		return c, fmt.Errorf("shared_cpu_list: %s", err)
+	}
+	return c, nil
+}
 
-		// cache size
-		s, err := ioutil.ReadFile(filepath.Join(p, d.Name(), "size"))
-		if err != nil {
-			return err
+// readSize reads a file containing a cache size, e.g. 32K, and returns it in
+// bytes. If the file doesn't exist, 0 is returned.
+func readSize(path string) (int64, error) {
+	v, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
 		}
+		return 0, err
+	}
+	return ParseCacheSize(string(v))
+}
 
-		// add the info
-		cpu.Cache[cacheID] = string(s[:len(s)-1])
-		cpu.CacheIDs = append(cpu.CacheIDs, cacheID)
+// ParseCacheSize parses a cache size as sysfs formats it, e.g. 32K, and
+// returns it in bytes. The size may have a K, M, or G suffix. Leading and
+// trailing white space is ignored; an empty string results in 0.
+func ParseCacheSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
 	}
-	// sort the cache names
-	sort.Strings(cpu.CacheIDs)
+	var mult int64 = 1
+	switch s[len(s)-1] {
+	case 'K':
+		mult = 1 << 10
+	case 'M':
+		mult = 1 << 20
+	case 'G':
+		mult = 1 << 30
+	}
+	if mult > 1 {
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * mult, nil
+}
 
-	return nil
+// FormatCacheSize formats a cache size, in bytes, the way sysfs does, e.g.
+// 32K; it is the inverse of ParseCacheSize. A size of 0 results in an empty
+// string.
+func FormatCacheSize(n int64) string {
+	switch {
+	case n == 0:
+		return ""
+	case n%(1<<30) == 0:
+		return strconv.FormatInt(n>>30, 10) + "G"
+	case n%(1<<20) == 0:
+		return strconv.FormatInt(n>>20, 10) + "M"
+	case n%(1<<10) == 0:
+		return strconv.FormatInt(n>>10, 10) + "K"
+	}
+	return strconv.FormatInt(n, 10)
 }
 
 // Possible: CPUs that have been allocated resources and can be brought online
 // if they are present. [cpu_possible_mask]
 // from: Documentation/cputopology.txt
-func (prof *Profiler) Possible() (string, error) {
-	p, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, Possible))
-	if err != nil {
-		return "", err
-	}
-	return string(p[:len(p)-1]), nil
+func (prof *Profiler) Possible() (joefriday.Set, error) {
+	return prof.cpuList(Possible)
 }
 
 // Present: CPUs that have been identified as being present in the system.
 // [cpu_present_mask]
 // from: Documentation/cputopology.txt
-func (prof *Profiler) Present() (string, error) {
-	p, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, Present))
-	if err != nil {
-		return "", err
-	}
-	return string(p[:len(p)-1]), nil
+func (prof *Profiler) Present() (joefriday.Set, error) {
+	return prof.cpuList(Present)
 }
 
 // Online: CPUs that are online and being scheduled [cpu_online_mask]
 // from: Documentation/cputopology.txt
-func (prof *Profiler) Online() (string, error) {
-	p, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, Online))
-	if err != nil {
-		return "", err
-	}
-	return string(p[:len(p)-1]), nil
+func (prof *Profiler) Online() (joefriday.Set, error) {
+	return prof.cpuList(Online)
 }
 
 // Offline: CPUs that are not online because they have been HOTPLUGGED off
@@ -355,16 +523,70 @@ func (prof *Profiler) Online() (string, error) {
 // from: Documentation/cputopology.txt
 //
 // This file may not exist or may only contain a new line char, '\n', neither
-// of these conditions are error states and will result in an empty string.
-func (prof *Profiler) Offline() (string, error) {
-	p, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, Offline))
+// of these conditions are error states and will result in an empty set.
+func (prof *Profiler) Offline() (joefriday.Set, error) {
+	s, err := prof.cpuList(Offline)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return "", nil
+			return joefriday.Set{}, nil
 		}
-		return "", err
+		return joefriday.Set{}, err
+	}
+	return s, nil
+}
+
+// Isolated: CPUs that are isolated from the kernel's scheduler using the
+// isolcpus kernel parameter.
+//
+// This file may not exist on older kernels; that is not an error state and
+// will result in an empty set.
+func (prof *Profiler) Isolated() (joefriday.Set, error) {
+	s, err := prof.cpuList(Isolated)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return joefriday.Set{}, nil
+		}
+		return joefriday.Set{}, err
+	}
+	return s, nil
+}
+
+// NohzFull: CPUs that are in adaptive-tick mode, set using the nohz_full
+// kernel parameter.
+//
+// This file only exists if the kernel was built with CONFIG_NO_HZ_FULL. If it
+// doesn't exist, or nohz_full wasn't set, in which case the file contains
+// "(null)", the result will be an empty set.
+func (prof *Profiler) NohzFull() (joefriday.Set, error) {
+	p, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, NohzFull))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return joefriday.Set{}, nil
+		}
+		return joefriday.Set{}, err
+	}
+	v := strings.TrimSpace(string(p))
+	if v == "(null)" {
+		return joefriday.Set{}, nil
+	}
+	s, err := joefriday.ParseSet(v)
+	if err != nil {
+		return joefriday.Set{}, fmt.Errorf("%s: %s", NohzFull, err)
+	}
+	return s, nil
+}
+
+// cpuList reads the cpu list in the named file of the sysfs cpu tree.
+func (prof *Profiler) cpuList(name string) (joefriday.Set, error) {
+	p, err := ioutil.ReadFile(filepath.Join(prof.cpuPath, name))
+	if err != nil {
+		return joefriday.Set{}, err
+	}
+	s, err := joefriday.ParseSet(string(p))
+	if err != nil {
+		return joefriday.Set{}, fmt.Errorf("%s: %s", name, err)
 	}
-	return string(p[:len(p)-1]), nil
+	return s, nil
 }
 
 // SysFSSystemPath enables overriding the default value. This is for testing
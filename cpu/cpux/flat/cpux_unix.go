@@ -28,6 +28,7 @@ import (
 	"sync"
 
 	fb "github.com/google/flatbuffers/go"
+	"github.com/c3sr/joefriday"
 	"github.com/c3sr/joefriday/cpu/cpux"
 	"github.com/c3sr/joefriday/cpu/cpux/flat/structs"
 )
@@ -72,10 +73,26 @@ func Get() (p []byte, err error) {
 func (p *Profiler) Serialize(cpus *cpux.CPUs) []byte {
 	// ensure the Builder is in a usable state.
 	p.Builder.Reset()
-	possible := p.Builder.CreateString(cpus.Possible)
-	online := p.Builder.CreateString(cpus.Online)
-	offline := p.Builder.CreateString(cpus.Offline)
-	present := p.Builder.CreateString(cpus.Present)
+	possible := p.Builder.CreateString(cpus.Possible.String())
+	online := p.Builder.CreateString(cpus.Online.String())
+	offline := p.Builder.CreateString(cpus.Offline.String())
+	present := p.Builder.CreateString(cpus.Present.String())
+	isolated := p.Builder.CreateString(cpus.Isolated.String())
+	nohzFull := p.Builder.CreateString(cpus.NohzFull.String())
+	smtControl := p.Builder.CreateString(cpus.SMT.Control)
+	structs.SMTStart(p.Builder)
+	structs.SMTAddControl(p.Builder, smtControl)
+	structs.SMTAddActive(p.Builder, cpus.SMT.Active)
+	smt := structs.SMTEnd(p.Builder)
+	vulnsF := make([]fb.UOffsetT, len(cpus.Vulnerabilities))
+	for i := range cpus.Vulnerabilities {
+		vulnsF[i] = p.SerializeVulnerability(&cpus.Vulnerabilities[i])
+	}
+	structs.CPUsStartVulnerabilitiesVector(p.Builder, len(vulnsF))
+	for i := len(vulnsF) - 1; i >= 0; i-- {
+		p.Builder.PrependUOffsetT(vulnsF[i])
+	}
+	vulnsV := p.Builder.EndVector(len(vulnsF))
 	uoffs := make([]fb.UOffsetT, len(cpus.CPU))
 	for i, cpu := range cpus.CPU {
 		uoffs[i] = p.SerializeCPU(&cpu)
@@ -92,6 +109,10 @@ func (p *Profiler) Serialize(cpus *cpux.CPUs) []byte {
 	structs.CPUsAddOffline(p.Builder, offline)
 	structs.CPUsAddPresent(p.Builder, present)
 	structs.CPUsAddCPU(p.Builder, cpusV)
+	structs.CPUsAddIsolated(p.Builder, isolated)
+	structs.CPUsAddNohzFull(p.Builder, nohzFull)
+	structs.CPUsAddSMT(p.Builder, smt)
+	structs.CPUsAddVulnerabilities(p.Builder, vulnsV)
 	p.Builder.Finish(structs.CPUsEnd(p.Builder))
 	b := p.Builder.Bytes[p.Builder.Head():]
 	// copy them (otherwise gets lost in reset)
@@ -103,13 +124,9 @@ func (p *Profiler) Serialize(cpus *cpux.CPUs) []byte {
 // SerializeCPU serializes a CPU using flatbuffers and returns the resulting
 // UOffsetT.
 func (p *Profiler) SerializeCPU(cpu *cpux.CPU) fb.UOffsetT {
-	uoffs := make([]fb.UOffsetT, len(cpu.CacheIDs))
-	// serialize cache info in order; the flatbuffer table will have the info
-	// in order so a separate cache ID list isn't necessary for flatbuffers.
-	for i, id := range cpu.CacheIDs {
-		// If the ID doesn't exist, the 0 value will be used
-		inf := cpu.Cache[id]
-		uoffs[i] = p.SerializeCache(id, inf)
+	uoffs := make([]fb.UOffsetT, len(cpu.Cache))
+	for i := range cpu.Cache {
+		uoffs[i] = p.SerializeCache(&cpu.Cache[i])
 	}
 	structs.CPUStartCacheVector(p.Builder, len(uoffs))
 	for i := len(uoffs) - 1; i >= 0; i-- {
@@ -128,15 +145,37 @@ func (p *Profiler) SerializeCPU(cpu *cpux.CPU) fb.UOffsetT {
 
 // SerializeCache serializes a cache entry using flatbuffers and returns the
 // resulting UOffsetT.
-func (p *Profiler) SerializeCache(id, inf string) fb.UOffsetT {
-	cID := p.Builder.CreateString(id)
-	cInf := p.Builder.CreateString(inf)
+func (p *Profiler) SerializeCache(c *cpux.Cache) fb.UOffsetT {
+	id := p.Builder.CreateString(c.ID)
+	size := p.Builder.CreateString(cpux.FormatCacheSize(c.Size))
+	typ := p.Builder.CreateString(c.Type)
+	shared := p.Builder.CreateString(c.SharedCPUList.String())
 	structs.CacheInfStart(p.Builder)
-	structs.CacheInfAddID(p.Builder, cID)
-	structs.CacheInfAddSize(p.Builder, cInf)
+	structs.CacheInfAddID(p.Builder, id)
+	structs.CacheInfAddSize(p.Builder, size)
+	structs.CacheInfAddSizeBytes(p.Builder, c.Size)
+	structs.CacheInfAddLevel(p.Builder, c.Level)
+	structs.CacheInfAddType(p.Builder, typ)
+	structs.CacheInfAddWays(p.Builder, c.Ways)
+	structs.CacheInfAddLineSize(p.Builder, c.LineSize)
+	structs.CacheInfAddSets(p.Builder, c.Sets)
+	structs.CacheInfAddSharedCPUList(p.Builder, shared)
 	return structs.CacheInfEnd(p.Builder)
 }
 
+// SerializeVulnerability serializes a vulnerability using flatbuffers and
+// returns the resulting UOffsetT.
+func (p *Profiler) SerializeVulnerability(v *cpux.Vulnerability) fb.UOffsetT {
+	name := p.Builder.CreateString(v.Name)
+	status := p.Builder.CreateString(v.Status)
+	details := p.Builder.CreateString(v.Details)
+	structs.VulnerabilityStart(p.Builder)
+	structs.VulnerabilityAddName(p.Builder, name)
+	structs.VulnerabilityAddStatus(p.Builder, status)
+	structs.VulnerabilityAddDetails(p.Builder, details)
+	return structs.VulnerabilityEnd(p.Builder)
+}
+
 // Serialize cpux.CPUs using the package global profiler.
 func Serialize(cpus *cpux.CPUs) (p []byte) {
 	stdMu.Lock()
@@ -157,9 +196,28 @@ func Deserialize(p []byte) *cpux.CPUs {
 	fCache := &structs.CacheInf{}
 	cpu := cpux.CPU{}
 	cpus.Sockets = fcpus.Sockets()
-	cpus.Possible = string(fcpus.Possible())
-	cpus.Online = string(fcpus.Online())
-	cpus.Present = string(fcpus.Present())
+	cpus.Possible = parseSet(fcpus.Possible())
+	cpus.Online = parseSet(fcpus.Online())
+	cpus.Offline = parseSet(fcpus.Offline())
+	cpus.Present = parseSet(fcpus.Present())
+	cpus.Isolated = parseSet(fcpus.Isolated())
+	cpus.NohzFull = parseSet(fcpus.NohzFull())
+	fSMT := &structs.SMT{}
+	if fcpus.SMT(fSMT) != nil {
+		cpus.SMT.Control = string(fSMT.Control())
+		cpus.SMT.Active = fSMT.Active()
+	}
+	fVuln := &structs.Vulnerability{}
+	for i := 0; i < fcpus.VulnerabilitiesLength(); i++ {
+		if !fcpus.Vulnerabilities(fVuln, i) {
+			continue
+		}
+		cpus.Vulnerabilities = append(cpus.Vulnerabilities, cpux.Vulnerability{
+			Name:    string(fVuln.Name()),
+			Status:  string(fVuln.Status()),
+			Details: string(fVuln.Details()),
+		})
+	}
 	for i := 0; i < l; i++ {
 		if !fcpus.CPU(fCPU, i) {
 			continue
@@ -169,16 +227,48 @@ func Deserialize(p []byte) *cpux.CPUs {
 		cpu.MHzMin = fCPU.MHzMin()
 		cpu.MHzMax = fCPU.MHzMax()
 		caches := fCPU.CacheLength()
-		cpu.CacheIDs = make([]string, 0, caches)
-		cpu.Cache = make(map[string]string, caches)
+		cpu.Cache = make([]cpux.Cache, 0, caches)
 		for j := 0; j < caches; j++ {
 			if !fCPU.Cache(fCache, j) {
 				continue
 			}
-			cpu.CacheIDs = append(cpu.CacheIDs, string(fCache.ID()))
-			cpu.Cache[cpu.CacheIDs[j]] = string(fCache.Size())
+			cpu.Cache = append(cpu.Cache, cpux.Cache{
+				ID:            string(fCache.ID()),
+				Level:         fCache.Level(),
+				Type:          string(fCache.Type()),
+				Size:          cacheSize(fCache),
+				Ways:          fCache.Ways(),
+				LineSize:      fCache.LineSize(),
+				Sets:          fCache.Sets(),
+				SharedCPUList: parseSet(fCache.SharedCPUList()),
+			})
 		}
 		cpus.CPU = append(cpus.CPU, cpu)
 	}
 	return cpus
 }
+
+// cacheSize returns the cache's size in bytes. Buffers serialized before
+// SizeBytes was added only have the sysfs formatted Size; for those the size
+// is parsed from it. If Size can't be parsed, 0 is returned.
+func cacheSize(c *structs.CacheInf) int64 {
+	if n := c.SizeBytes(); n != 0 {
+		return n
+	}
+	n, err := cpux.ParseCacheSize(string(c.Size()))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// parseSet parses a serialized cpu list. The list was created by
+// joefriday.Set.String so a parse error, which can only occur if the bytes
+// weren't serialized by this package, results in an empty set.
+func parseSet(p []byte) joefriday.Set {
+	s, err := joefriday.ParseSet(string(p))
+	if err != nil {
+		return joefriday.Set{}
+	}
+	return s
+}
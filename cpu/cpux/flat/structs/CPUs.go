@@ -84,7 +84,59 @@ func (rcv *CPUs) CPULength() int {
 	return 0
 }
 
-func CPUsStart(builder *flatbuffers.Builder) { builder.StartObject(6) }
+func (rcv *CPUs) Isolated() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPUs) NohzFull() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CPUs) SMT(obj *SMT) *SMT {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(SMT)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		return obj
+	}
+	return nil
+}
+
+func (rcv *CPUs) Vulnerabilities(obj *Vulnerability, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(22))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+	if obj == nil {
+		obj = new(Vulnerability)
+	}
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *CPUs) VulnerabilitiesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(22))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func CPUsStart(builder *flatbuffers.Builder) { builder.StartObject(10) }
 func CPUsAddSockets(builder *flatbuffers.Builder, Sockets int32) { builder.PrependInt32Slot(0, Sockets, 0) }
 func CPUsAddPossible(builder *flatbuffers.Builder, Possible flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(Possible), 0) }
 func CPUsAddPresent(builder *flatbuffers.Builder, Present flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(Present), 0) }
@@ -93,4 +145,10 @@ func CPUsAddOffline(builder *flatbuffers.Builder, Offline flatbuffers.UOffsetT)
 func CPUsAddCPU(builder *flatbuffers.Builder, CPU flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(5, flatbuffers.UOffsetT(CPU), 0) }
 func CPUsStartCPUVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT { return builder.StartVector(4, numElems, 4)
 }
+func CPUsAddIsolated(builder *flatbuffers.Builder, Isolated flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(Isolated), 0) }
+func CPUsAddNohzFull(builder *flatbuffers.Builder, NohzFull flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(7, flatbuffers.UOffsetT(NohzFull), 0) }
+func CPUsAddSMT(builder *flatbuffers.Builder, SMT flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(8, flatbuffers.UOffsetT(SMT), 0) }
+func CPUsAddVulnerabilities(builder *flatbuffers.Builder, Vulnerabilities flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(9, flatbuffers.UOffsetT(Vulnerabilities), 0) }
+func CPUsStartVulnerabilitiesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT { return builder.StartVector(4, numElems, 4)
+}
 func CPUsEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT { return builder.EndObject() }
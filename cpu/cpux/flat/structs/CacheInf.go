@@ -30,7 +30,70 @@ func (rcv *CacheInf) Size() []byte {
 	return nil
 }
 
-func CacheInfStart(builder *flatbuffers.Builder) { builder.StartObject(2) }
+func (rcv *CacheInf) SizeBytes() int64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.GetInt64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CacheInf) Level() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CacheInf) Type() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CacheInf) Ways() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CacheInf) LineSize() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CacheInf) Sets() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CacheInf) SharedCPUList() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func CacheInfStart(builder *flatbuffers.Builder) { builder.StartObject(9) }
 func CacheInfAddID(builder *flatbuffers.Builder, ID flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(ID), 0) }
 func CacheInfAddSize(builder *flatbuffers.Builder, Size flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(Size), 0) }
+func CacheInfAddSizeBytes(builder *flatbuffers.Builder, SizeBytes int64) { builder.PrependInt64Slot(2, SizeBytes, 0) }
+func CacheInfAddLevel(builder *flatbuffers.Builder, Level int32) { builder.PrependInt32Slot(3, Level, 0) }
+func CacheInfAddType(builder *flatbuffers.Builder, Type flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(4, flatbuffers.UOffsetT(Type), 0) }
+func CacheInfAddWays(builder *flatbuffers.Builder, Ways int32) { builder.PrependInt32Slot(5, Ways, 0) }
+func CacheInfAddLineSize(builder *flatbuffers.Builder, LineSize int32) { builder.PrependInt32Slot(6, LineSize, 0) }
+func CacheInfAddSets(builder *flatbuffers.Builder, Sets int32) { builder.PrependInt32Slot(7, Sets, 0) }
+func CacheInfAddSharedCPUList(builder *flatbuffers.Builder, SharedCPUList flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(8, flatbuffers.UOffsetT(SharedCPUList), 0) }
 func CacheInfEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT { return builder.EndObject() }
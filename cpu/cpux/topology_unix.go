@@ -0,0 +1,287 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpux
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/c3sr/joefriday"
+)
+
+// Topology is the system's cpu topology as a tree: Socket -> Die -> Core ->
+// Thread, where each thread is a logical cpu. CPU holds the topology
+// information of each logical cpu, in ascending order, for looking up a
+// cpu's siblings and cache groups. Only online cpus are included as the
+// kernel doesn't provide topology information for offline cpus.
+type Topology struct {
+	Socket []Socket     `json:"socket"`
+	CPU    []LogicalCPU `json:"cpu"`
+}
+
+// Socket is a physical package.
+type Socket struct {
+	ID  int32         `json:"id"`
+	CPU joefriday.Set `json:"cpu"`
+	Die []Die         `json:"die"`
+}
+
+// Die is a die within a socket. Systems that don't expose die_id have a single
+// die, with an ID of 0, per socket.
+type Die struct {
+	ID   int32         `json:"id"`
+	CPU  joefriday.Set `json:"cpu"`
+	Core []Core        `json:"core"`
+}
+
+// Core is a core within a die. Thread holds the logical cpus, hardware
+// threads, of the core. ClusterID is the id of the cluster the core belongs
+// to, if the system has clusters, otherwise it is -1.
+type Core struct {
+	ID        int32         `json:"id"`
+	ClusterID int32         `json:"cluster_id"`
+	Thread    joefriday.Set `json:"thread"`
+}
+
+// LogicalCPU holds the topology information of a single logical cpu. The
+// sibling lists include the cpu itself. NodeID is the NUMA node the cpu
+// belongs to; if the system doesn't have NUMA nodes, it is -1.
+type LogicalCPU struct {
+	ID              int32         `json:"id"`
+	SocketID        int32         `json:"socket_id"`
+	DieID           int32         `json:"die_id"`
+	ClusterID       int32         `json:"cluster_id"`
+	CoreID          int32         `json:"core_id"`
+	NodeID          int32         `json:"node_id"`
+	ThreadSiblings  joefriday.Set `json:"thread_siblings"`
+	CoreSiblings    joefriday.Set `json:"core_siblings"`
+	DieSiblings     joefriday.Set `json:"die_siblings"`
+	ClusterSiblings joefriday.Set `json:"cluster_siblings"`
+	Cache           []Cache       `json:"cache"`
+}
+
+// LogicalCPU returns the topology information for logical cpu x. A false
+// will be returned if x isn't in the topology, e.g. it's offline.
+func (t *Topology) LogicalCPU(x int32) (cpu *LogicalCPU, found bool) {
+	// cpus are in order and usually contiguous; only search if x isn't at its
+	// index.
+	if x >= 0 && int(x) < len(t.CPU) && t.CPU[x].ID == x {
+		return &t.CPU[x], true
+	}
+	i := sort.Search(len(t.CPU), func(i int) bool { return t.CPU[i].ID >= x })
+	if i < len(t.CPU) && t.CPU[i].ID == x {
+		return &t.CPU[i], true
+	}
+	return nil, false
+}
+
+// ThreadSiblings returns the logical cpus that are on the same core as cpu x,
+// including x. The returned set is a copy.
+func (t *Topology) ThreadSiblings(x int32) joefriday.Set {
+	cpu, ok := t.LogicalCPU(x)
+	if !ok {
+		return joefriday.Set{}
+	}
+	return cpu.ThreadSiblings.Clone()
+}
+
+// CoreSiblings returns the logical cpus that are in the same socket as cpu x,
+// including x. The returned set is a copy.
+func (t *Topology) CoreSiblings(x int32) joefriday.Set {
+	cpu, ok := t.LogicalCPU(x)
+	if !ok {
+		return joefriday.Set{}
+	}
+	return cpu.CoreSiblings.Clone()
+}
+
+// CacheGroups returns the caches used by cpu x; each cache's SharedCPUList
+// is the group of logical cpus that share the cache.
+func (t *Topology) CacheGroups(x int32) []Cache {
+	cpu, ok := t.LogicalCPU(x)
+	if !ok {
+		return nil
+	}
+	return cpu.Cache
+}
+
+// Caches returns the caches of the cpus in the topology. Caches that are
+// shared by multiple cpus are only included once.
+func (t *Topology) Caches() []Cache {
+	var caches [][]Cache
+	for i := range t.CPU {
+		caches = append(caches, t.CPU[i].Cache)
+	}
+	return uniqueCaches(caches...)
+}
+
+// Topology returns the topology of the system's online cpus.
+func (prof *Profiler) Topology() (*Topology, error) {
+	online, err := prof.Online()
+	if err != nil {
+		return nil, err
+	}
+	t := &Topology{CPU: make([]LogicalCPU, 0, online.Count())}
+	for _, x := range online.Members() {
+		var cpu LogicalCPU
+		err = prof.logicalCPU(int32(x), &cpu)
+		if err != nil {
+			return nil, err
+		}
+		t.CPU = append(t.CPU, cpu)
+		t.add(&t.CPU[len(t.CPU)-1])
+	}
+	return t, nil
+}
+
+// add adds the logical cpu to the topology tree.
+func (t *Topology) add(cpu *LogicalCPU) {
+	var s *Socket
+	for i := range t.Socket {
+		if t.Socket[i].ID == cpu.SocketID {
+			s = &t.Socket[i]
+			break
+		}
+	}
+	if s == nil {
+		t.Socket = append(t.Socket, Socket{ID: cpu.SocketID})
+		s = &t.Socket[len(t.Socket)-1]
+	}
+	s.CPU.Add(int(cpu.ID))
+	var d *Die
+	for i := range s.Die {
+		if s.Die[i].ID == cpu.DieID {
+			d = &s.Die[i]
+			break
+		}
+	}
+	if d == nil {
+		s.Die = append(s.Die, Die{ID: cpu.DieID})
+		d = &s.Die[len(s.Die)-1]
+	}
+	d.CPU.Add(int(cpu.ID))
+	for i := range d.Core {
+		if d.Core[i].ID == cpu.CoreID {
+			d.Core[i].Thread.Add(int(cpu.ID))
+			return
+		}
+	}
+	d.Core = append(d.Core, Core{ID: cpu.CoreID, ClusterID: cpu.ClusterID, Thread: joefriday.NewSet(int(cpu.ID))})
+}
+
+// logicalCPU gets the topology information of logical cpu x.
+func (prof *Profiler) logicalCPU(x int32, cpu *LogicalCPU) error {
+	var err error
+	p := filepath.Join(prof.cpuXPath(int(x)), "topology")
+	cpu.ID = x
+	cpu.SocketID, err = prof.physicalPackageID(int(x))
+	if err != nil {
+		return err
+	}
+	cpu.CoreID, err = prof.coreID(int(x))
+	if err != nil {
+		return err
+	}
+	// die_id and cluster_id aren't available on older kernels.
+	cpu.DieID, err = readID(filepath.Join(p, "die_id"), 0)
+	if err != nil {
+		return fmt.Errorf("cpu%d die_id: %s", x, err)
+	}
+	cpu.ClusterID, err = readID(filepath.Join(p, "cluster_id"), -1)
+	if err != nil {
+		return fmt.Errorf("cpu%d cluster_id: %s", x, err)
+	}
+	cpu.ThreadSiblings, err = readList(filepath.Join(p, "thread_siblings_list"))
+	if err != nil {
+		return fmt.Errorf("cpu%d thread_siblings_list: %s", x, err)
+	}
+	cpu.CoreSiblings, err = readList(filepath.Join(p, "core_siblings_list"))
+	if err != nil {
+		return fmt.Errorf("cpu%d core_siblings_list: %s", x, err)
+	}
+	cpu.DieSiblings, err = readList(filepath.Join(p, "die_cpus_list"))
+	if err != nil {
+		return fmt.Errorf("cpu%d die_cpus_list: %s", x, err)
+	}
+	if cpu.DieSiblings.IsEmpty() {
+		// without dies, the socket is the die.
+		cpu.DieSiblings = cpu.CoreSiblings.Clone()
+	}
+	cpu.ClusterSiblings, err = readList(filepath.Join(p, "cluster_cpus_list"))
+	if err != nil {
+		return fmt.Errorf("cpu%d cluster_cpus_list: %s", x, err)
+	}
+	cpu.NodeID, err = prof.nodeID(x)
+	if err != nil {
+		return err
+	}
+	cpu.Cache, err = prof.caches(int(x))
+	return err
+}
+
+// nodeID returns the NUMA node of logical cpu x; the cpuX dir has a nodeN
+// entry for the node the cpu belongs to. If there isn't a nodeN entry, -1 is
+// returned.
+func (prof *Profiler) nodeID(x int32) (int32, error) {
+	entries, err := ioutil.ReadDir(prof.cpuXPath(int(x)))
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), "node") {
+			continue
+		}
+		n, err := strconv.Atoi(e.Name()[4:])
+		if err != nil {
+			continue // not a nodeN entry
+		}
+		return int32(n), nil
+	}
+	return -1, nil
+}
+
+// readID reads a file containing an integer id. If the file doesn't exist,
+// the default value, def, is returned.
+func readID(path string, def int32) (int32, error) {
+	v, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return def, nil
+		}
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(v)))
+	if err != nil {
+		return 0, err
+	}
+	return int32(n), nil
+}
+
+// readList reads a file containing a cpu list, e.g. 0-3,8-11. If the file
+// doesn't exist, an empty set is returned.
+func readList(path string) (joefriday.Set, error) {
+	v, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return joefriday.Set{}, nil
+		}
+		return joefriday.Set{}, err
+	}
+	return joefriday.ParseSet(string(v))
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpux
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Vulnerability statuses as reported by the kernel. KVMMitigation is only
+// reported for itlb_multihit, when the mitigation is done by KVM.
+const (
+	NotAffected   = "Not affected"
+	Vulnerable    = "Vulnerable"
+	Mitigation    = "Mitigation"
+	KVMMitigation = "KVM: Mitigation"
+	Unknown       = "Unknown"
+)
+
+// statuses are the known statuses in the order that they are matched: a
+// status that is a prefix of another is after it.
+var statuses = []string{KVMMitigation, NotAffected, Vulnerable, Mitigation, Unknown}
+
+// Vulnerability is the state of a cpu vulnerability, from
+// /sys/devices/system/cpu/vulnerabilities. Name is the vulnerability's file
+// name, e.g. spectre_v2. Status is either Not affected, Vulnerable,
+// Mitigation, KVM: Mitigation, or Unknown and Details is the rest of the
+// kernel's report, e.g. the mitigation in use: for "Mitigation: PTI", the
+// Status is Mitigation and the Details is PTI.
+type Vulnerability struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Details string `json:"details"`
+}
+
+// SMT is the state of simultaneous multithreading. Control is one of on, off,
+// forceoff, notsupported, or notimplemented. Active is whether or not SMT is
+// enabled and there are sibling threads online.
+type SMT struct {
+	Control string `json:"control"`
+	Active  bool   `json:"active"`
+}
+
+// Vulnerabilities returns the state of each of the cpu vulnerabilities known
+// to the kernel, sorted by name. If the vulnerabilities dir doesn't exist, no
+// vulnerabilities are returned.
+func (prof *Profiler) Vulnerabilities() ([]Vulnerability, error) {
+	p := filepath.Join(prof.cpuPath, Vulnerabilities)
+	files, err := ioutil.ReadDir(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var vulns []Vulnerability
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(p, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, ParseVulnerability(f.Name(), string(b)))
+	}
+	return vulns, nil
+}
+
+// ParseVulnerability parses the contents, v, of the named vulnerability's
+// file. The status is the known status that v starts with and the details
+// are everything after it and its separator, one of ':', ',', or ';', e.g.
+// "Vulnerable, IBPB: disabled, STIBP: disabled" has a status of Vulnerable
+// and details of "IBPB: disabled, STIBP: disabled". If v doesn't start with a
+// known status, the status is Unknown and the details are the entire
+// contents.
+func ParseVulnerability(name, v string) Vulnerability {
+	vuln := Vulnerability{Name: name}
+	v = strings.TrimSpace(v)
+	for _, status := range statuses {
+		if !strings.HasPrefix(v, status) {
+			continue
+		}
+		rest := v[len(status):]
+		// the status must be followed by a separator or the end of v.
+		if rest != "" && strings.IndexByte(":,; ", rest[0]) < 0 {
+			continue
+		}
+		vuln.Status = status
+		vuln.Details = strings.TrimSpace(strings.TrimLeft(rest, ":,; "))
+		return vuln
+	}
+	vuln.Status = Unknown
+	vuln.Details = v
+	return vuln
+}
+
+// SMT returns the system's SMT state. If the smt dir doesn't exist, the
+// zero value is returned.
+func (prof *Profiler) SMT() (SMT, error) {
+	var smt SMT
+	p := filepath.Join(prof.cpuPath, SMTDir)
+	b, err := ioutil.ReadFile(filepath.Join(p, "control"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return smt, nil
+		}
+		return smt, err
+	}
+	smt.Control = strings.TrimSpace(string(b))
+	b, err = ioutil.ReadFile(filepath.Join(p, "active"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return smt, nil
+		}
+		return smt, err
+	}
+	smt.Active = strings.TrimSpace(string(b)) == "1"
+	return smt, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interrupts handles Flatbuffer based processing of the number of
+// interrupts per IRQ for each CPU: /proc/interrupts. The counts are
+// aggregated since system boot. Instead of returning a Go struct, it returns
+// Flatbuffer serialized bytes. A function to deserialize the Flatbuffer
+// serialized bytes into an interrupts.Interrupts struct is provided.
+//
+// Note: the package name is interrupts and not the final element of the
+// import path (flat).
+package interrupts
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	irq "github.com/c3sr/joefriday/cpu/interrupts"
+	"github.com/c3sr/joefriday/cpu/interrupts/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the /proc/interrupts file as Flatbuffer
+// serialized bytes.
+type Profiler struct {
+	*irq.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized profiler that uses Flatbuffers.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := irq.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the current interrupt counts as Flatbuffer serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	inf, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(inf), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the current interrupt counts as Flatbuffer serialized bytes
+// using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+
+	return std.Get()
+}
+
+// Serialize interrupts.Interrupts using Flatbuffers.
+func (prof *Profiler) Serialize(inf *irq.Interrupts) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	irqsF := make([]fb.UOffsetT, len(inf.IRQ))
+	for i := 0; i < len(irqsF); i++ {
+		irqsF[i] = prof.SerializeIRQ(&inf.IRQ[i])
+	}
+	structs.InterruptsStartIRQVector(prof.Builder, len(irqsF))
+	for i := len(irqsF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(irqsF[i])
+	}
+	irqsV := prof.Builder.EndVector(len(irqsF))
+	structs.InterruptsStartCPUVector(prof.Builder, len(inf.CPU))
+	for i := len(inf.CPU) - 1; i >= 0; i-- {
+		prof.Builder.PrependInt32(inf.CPU[i])
+	}
+	cpuV := prof.Builder.EndVector(len(inf.CPU))
+	structs.InterruptsStart(prof.Builder)
+	structs.InterruptsAddTimestamp(prof.Builder, inf.Timestamp)
+	structs.InterruptsAddCPU(prof.Builder, cpuV)
+	structs.InterruptsAddIRQ(prof.Builder, irqsV)
+	prof.Builder.Finish(structs.InterruptsEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// SerializeIRQ serializes an IRQ using Flatbuffers and returns the resulting
+// UOffsetT.
+func (prof *Profiler) SerializeIRQ(v *irq.IRQ) fb.UOffsetT {
+	id := prof.Builder.CreateString(v.ID)
+	chip := prof.Builder.CreateString(v.Chip)
+	hwirq := prof.Builder.CreateString(v.HWIRQ)
+	dev := prof.Builder.CreateString(v.Device)
+	structs.IRQStartCountVector(prof.Builder, len(v.Count))
+	for i := len(v.Count) - 1; i >= 0; i-- {
+		prof.Builder.PrependInt64(v.Count[i])
+	}
+	count := prof.Builder.EndVector(len(v.Count))
+	structs.IRQStart(prof.Builder)
+	structs.IRQAddID(prof.Builder, id)
+	structs.IRQAddNum(prof.Builder, v.Num)
+	structs.IRQAddCount(prof.Builder, count)
+	structs.IRQAddTotal(prof.Builder, v.Total)
+	structs.IRQAddChip(prof.Builder, chip)
+	structs.IRQAddHWIRQ(prof.Builder, hwirq)
+	structs.IRQAddDevice(prof.Builder, dev)
+	return structs.IRQEnd(prof.Builder)
+}
+
+// Serialize interrupts.Interrupts with Flatbuffers using the package's global
+// Profiler.
+func Serialize(inf *irq.Interrupts) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(inf), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// interrupts.Interrupts.
+func Deserialize(p []byte) *irq.Interrupts {
+	inf := &irq.Interrupts{}
+	irqF := &structs.IRQ{}
+	infF := structs.GetRootAsInterrupts(p, 0)
+	inf.Timestamp = infF.Timestamp()
+	inf.CPU = make([]int32, infF.CPULength())
+	for i := 0; i < len(inf.CPU); i++ {
+		inf.CPU[i] = infF.CPU(i)
+	}
+	inf.IRQ = make([]irq.IRQ, infF.IRQLength())
+	for i := 0; i < len(inf.IRQ); i++ {
+		var v irq.IRQ
+		if infF.IRQ(irqF, i) {
+			v.ID = string(irqF.ID())
+			v.Num = irqF.Num()
+			v.Count = make([]int64, irqF.CountLength())
+			for j := 0; j < len(v.Count); j++ {
+				v.Count[j] = irqF.Count(j)
+			}
+			v.Total = irqF.Total()
+			v.Chip = string(irqF.Chip())
+			v.HWIRQ = string(irqF.HWIRQ())
+			v.Device = string(irqF.Device())
+		}
+		inf.IRQ[i] = v
+	}
+	return inf
+}
+
+// Ticker delivers the system's interrupt counts at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
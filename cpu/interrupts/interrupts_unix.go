@@ -0,0 +1,393 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interrupts handles the processing of the number of interrupts per
+// IRQ for each CPU: /proc/interrupts. The counts are aggregated since system
+// boot. Interrupts per second, for each CPU, can be calculated using the
+// RateProfiler or RateTicker.
+package interrupts
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/helpers"
+)
+
+const procFile = "/proc/interrupts"
+
+// Interrupts holds the interrupt counts of each IRQ. CPU holds the number of
+// each CPU that has a column in /proc/interrupts; the per CPU counts of each
+// IRQ are in the same order.
+type Interrupts struct {
+	Timestamp int64   `json:"timestamp"`
+	CPU       []int32 `json:"cpu"`
+	IRQ       []IRQ   `json:"irq"`
+}
+
+// IRQ holds the information about a single /proc/interrupts row. For numbered
+// IRQs, Num is the IRQ number, Chip is the name of the interrupt controller,
+// HWIRQ is the hardware IRQ number and trigger type, and Device holds the
+// names of the devices using the IRQ. For rows that aren't numbered IRQs, e.g.
+// NMI, LOC, RES, Num is -1 and Device holds the description of the interrupt.
+// Some rows, e.g. ERR and MIS, are not per CPU; their Count only has one
+// element. Total is the sum of the counts.
+type IRQ struct {
+	ID     string  `json:"id"`
+	Num    int32   `json:"num"`
+	Count  []int64 `json:"count"`
+	Total  int64   `json:"total"`
+	Chip   string  `json:"chip"`
+	HWIRQ  string  `json:"hwirq"`
+	Device string  `json:"device"`
+}
+
+// Profiler is used to process the /proc/interrupts file.
+type Profiler struct {
+	joe.Procer
+	*joe.Buffer
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	proc, err := joe.NewProc(procFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Procer: proc, Buffer: joe.NewBuffer()}, nil
+}
+
+// Reset resources: after reset, the profiler is ready to be used again.
+func (prof *Profiler) Reset() error {
+	prof.Buffer.Reset()
+	return prof.Procer.Reset()
+}
+
+// Get returns the current interrupt counts.
+func (prof *Profiler) Get() (inf *Interrupts, err error) {
+	err = prof.Reset()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		i, pos, line int
+		n            uint64
+	)
+	inf = &Interrupts{Timestamp: time.Now().UTC().UnixNano()}
+	for {
+		prof.Line, err = prof.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			if err != bufio.ErrBufferFull {
+				return nil, &joe.ReadError{Err: err}
+			}
+			err = prof.LongLine(prof.Procer)
+			if err != nil {
+				return nil, err
+			}
+		}
+		line++
+		// the first line is the CPU header: CPU0 CPU1 ...
+		if line == 1 {
+			for _, f := range strings.Fields(string(prof.Line)) {
+				n, err = helpers.ParseUint([]byte(strings.TrimPrefix(f, "CPU")))
+				if err != nil {
+					return nil, &joe.ParseError{Info: fmt.Sprintf("line %d: %s", line, f), Err: err}
+				}
+				inf.CPU = append(inf.CPU, int32(n))
+			}
+			continue
+		}
+		prof.Line = joe.TrimLeadingSpaces(prof.Line)
+		// the id is everything up to the ':'
+		for i = 0; i < len(prof.Line); i++ {
+			if prof.Line[i] == ':' {
+				break
+			}
+		}
+		if i == len(prof.Line) {
+			continue
+		}
+		irq := IRQ{ID: string(prof.Line[:i]), Num: -1, Count: make([]int64, 0, len(inf.CPU))}
+		n, err = helpers.ParseUint(prof.Line[:i])
+		if err == nil {
+			irq.Num = int32(n)
+		}
+		pos = i + 1
+		// the counts: one per CPU; some rows only have one.
+		for len(irq.Count) < len(inf.CPU) {
+			for pos < len(prof.Line) && prof.Line[pos] == 0x20 {
+				pos++
+			}
+			for i = pos; i < len(prof.Line); i++ {
+				if prof.Line[i] == 0x20 || prof.Line[i] == '\n' {
+					break
+				}
+			}
+			if i == pos {
+				break
+			}
+			n, err = helpers.ParseUint(prof.Line[pos:i])
+			if err != nil {
+				// not a count; the rest of the line is the description.
+				break
+			}
+			irq.Count = append(irq.Count, int64(n))
+			irq.Total += int64(n)
+			pos = i
+		}
+		fields := strings.Fields(string(prof.Line[pos:]))
+		if irq.Num < 0 || len(fields) == 0 {
+			irq.Device = strings.Join(fields, " ")
+			inf.IRQ = append(inf.IRQ, irq)
+			continue
+		}
+		irq.Chip = fields[0]
+		fields = fields[1:]
+		// the hwirq, if present, is the irq number and trigger type, e.g. 2-edge.
+		if len(fields) > 0 && fields[0][0] >= '0' && fields[0][0] <= '9' {
+			irq.HWIRQ = fields[0]
+			fields = fields[1:]
+		}
+		irq.Device = strings.Join(fields, " ")
+		inf.IRQ = append(inf.IRQ, irq)
+	}
+	return inf, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the current interrupt counts using the package's global
+// Profiler.
+func Get() (inf *Interrupts, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Ticker delivers the system's interrupt counts at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *Interrupts
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *Interrupts), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	// ticker
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- s
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
+
+// Rates holds the number of interrupts per second of each IRQ. The TimeDelta
+// field holds the time elapsed, in nanoseconds, between the two snapshots
+// used to calculate the rates. CPU holds the number of each CPU; the per CPU
+// rates of each IRQ are in the same order.
+type Rates struct {
+	Timestamp int64     `json:"timestamp"`
+	TimeDelta int64     `json:"time_delta"`
+	CPU       []int32   `json:"cpu"`
+	IRQ       []IRQRate `json:"irq"`
+}
+
+// IRQRate holds the interrupts per second of a single IRQ; for each CPU and
+// the total.
+type IRQRate struct {
+	ID     string    `json:"id"`
+	Num    int32     `json:"num"`
+	Rate   []float32 `json:"rate"`
+	Total  float32   `json:"total"`
+	Device string    `json:"device"`
+}
+
+// CalculateRates returns the interrupts per second between two
+// /proc/interrupts snapshots. IRQs are matched by ID; an IRQ that doesn't
+// exist in the prior snapshot is not included. If the CPUs of the two
+// snapshots differ, e.g. a CPU was brought online, the rates are calculated
+// for the CPUs of the current snapshot; a CPU that isn't in the prior
+// snapshot will have a rate of 0. Any count that is lower than its prior
+// value is treated as not having changed.
+func CalculateRates(prior, cur *Interrupts) *Rates {
+	r := &Rates{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, CPU: cur.CPU, IRQ: make([]IRQRate, 0, len(cur.IRQ))}
+	if r.TimeDelta <= 0 {
+		return r
+	}
+	secs := float32(r.TimeDelta) / float32(time.Second)
+	// map the index of each current CPU column to the prior snapshot's column.
+	cols := make([]int, len(cur.CPU))
+	for i := range cur.CPU {
+		cols[i] = -1
+		for j := range prior.CPU {
+			if prior.CPU[j] == cur.CPU[i] {
+				cols[i] = j
+				break
+			}
+		}
+	}
+	for i := 0; i < len(cur.IRQ); i++ {
+		var p *IRQ
+		// irqs are usually in the same order; only search if they aren't.
+		if i < len(prior.IRQ) && prior.IRQ[i].ID == cur.IRQ[i].ID {
+			p = &prior.IRQ[i]
+		} else {
+			for j := 0; j < len(prior.IRQ); j++ {
+				if prior.IRQ[j].ID == cur.IRQ[i].ID {
+					p = &prior.IRQ[j]
+					break
+				}
+			}
+		}
+		if p == nil {
+			continue
+		}
+		rate := IRQRate{ID: cur.IRQ[i].ID, Num: cur.IRQ[i].Num, Rate: make([]float32, len(cur.IRQ[i].Count)), Device: cur.IRQ[i].Device}
+		for j, v := range cur.IRQ[i].Count {
+			col := j
+			if len(cur.IRQ[i].Count) == len(cols) {
+				col = cols[j]
+			}
+			if col < 0 || col >= len(p.Count) || v < p.Count[col] {
+				continue
+			}
+			rate.Rate[j] = float32(v-p.Count[col]) / secs
+			rate.Total += rate.Rate[j]
+		}
+		r.IRQ = append(r.IRQ, rate)
+	}
+	return r
+}
+
+// RateProfiler is used to calculate the interrupts per second; the last
+// /proc/interrupts snapshot is kept so that the rates can be calculated.
+type RateProfiler struct {
+	*Profiler
+	prior *Interrupts
+}
+
+// NewRateProfiler returns an initialized RateProfiler; ready to use. The
+// initial /proc/interrupts snapshot is taken during initialization.
+func NewRateProfiler() (prof *RateProfiler, err error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	inf, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &RateProfiler{Profiler: p, prior: inf}, nil
+}
+
+// Get returns the interrupts per second since the last time the RateProfiler
+// was used. If this is the first use, the rates since the RateProfiler was
+// created are returned.
+func (prof *RateProfiler) Get() (r *Rates, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	r = CalculateRates(prof.prior, cur)
+	prof.prior = cur
+	return r, nil
+}
+
+// RateTicker delivers the system's interrupts per second at intervals.
+type RateTicker struct {
+	*joe.Ticker
+	Data chan *Rates
+	*RateProfiler
+}
+
+// NewRateTicker returns a new RateTicker containing a Data channel that
+// delivers the data at intervals and an error channel that delivers any
+// errors encountered. Stop the ticker to signal the ticker to stop running.
+// Stopping the ticker does not close the Data channel; call Close to close
+// both the ticker and the data channel.
+func NewRateTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewRateProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := RateTicker{Ticker: joe.NewTicker(d), Data: make(chan *Rates), RateProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *RateTicker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- r
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *RateTicker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,200 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package softirqs handles Flatbuffer based processing of the number of
+// softirqs, by type, serviced by each CPU: /proc/softirqs. The counts are
+// aggregated since system boot. Instead of returning a Go struct, it returns
+// Flatbuffer serialized bytes. A function to deserialize the Flatbuffer
+// serialized bytes into a softirqs.SoftIRQs struct is provided.
+//
+// Note: the package name is softirqs and not the final element of the import
+// path (flat).
+package softirqs
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	sirq "github.com/c3sr/joefriday/cpu/softirqs"
+	"github.com/c3sr/joefriday/cpu/softirqs/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the /proc/softirqs file as Flatbuffer
+// serialized bytes.
+type Profiler struct {
+	*sirq.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized profiler that uses Flatbuffers.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := sirq.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the current softirq counts as Flatbuffer serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	s, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(s), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the current softirq counts as Flatbuffer serialized bytes
+// using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+
+	return std.Get()
+}
+
+// Serialize softirqs.SoftIRQs using Flatbuffers.
+func (prof *Profiler) Serialize(s *sirq.SoftIRQs) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	cpusF := make([]fb.UOffsetT, len(s.CPU))
+	for i := 0; i < len(cpusF); i++ {
+		structs.CPUStart(prof.Builder)
+		structs.CPUAddID(prof.Builder, s.CPU[i].ID)
+		structs.CPUAddHI(prof.Builder, s.CPU[i].HI)
+		structs.CPUAddTimer(prof.Builder, s.CPU[i].Timer)
+		structs.CPUAddNetTX(prof.Builder, s.CPU[i].NetTX)
+		structs.CPUAddNetRX(prof.Builder, s.CPU[i].NetRX)
+		structs.CPUAddBlock(prof.Builder, s.CPU[i].Block)
+		structs.CPUAddIRQPoll(prof.Builder, s.CPU[i].IRQPoll)
+		structs.CPUAddTasklet(prof.Builder, s.CPU[i].Tasklet)
+		structs.CPUAddSched(prof.Builder, s.CPU[i].Sched)
+		structs.CPUAddHRTimer(prof.Builder, s.CPU[i].HRTimer)
+		structs.CPUAddRCU(prof.Builder, s.CPU[i].RCU)
+		cpusF[i] = structs.CPUEnd(prof.Builder)
+	}
+	structs.SoftIRQsStartCPUVector(prof.Builder, len(cpusF))
+	for i := len(cpusF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(cpusF[i])
+	}
+	cpusV := prof.Builder.EndVector(len(cpusF))
+	structs.SoftIRQsStart(prof.Builder)
+	structs.SoftIRQsAddTimestamp(prof.Builder, s.Timestamp)
+	structs.SoftIRQsAddCPU(prof.Builder, cpusV)
+	prof.Builder.Finish(structs.SoftIRQsEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// Serialize softirqs.SoftIRQs with Flatbuffers using the package's global
+// Profiler.
+func Serialize(s *sirq.SoftIRQs) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(s), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// softirqs.SoftIRQs.
+func Deserialize(p []byte) *sirq.SoftIRQs {
+	s := &sirq.SoftIRQs{}
+	cpuF := &structs.CPU{}
+	sF := structs.GetRootAsSoftIRQs(p, 0)
+	s.Timestamp = sF.Timestamp()
+	s.CPU = make([]sirq.CPU, sF.CPULength())
+	for i := 0; i < len(s.CPU); i++ {
+		var cpu sirq.CPU
+		if sF.CPU(cpuF, i) {
+			cpu.ID = cpuF.ID()
+			cpu.HI = cpuF.HI()
+			cpu.Timer = cpuF.Timer()
+			cpu.NetTX = cpuF.NetTX()
+			cpu.NetRX = cpuF.NetRX()
+			cpu.Block = cpuF.Block()
+			cpu.IRQPoll = cpuF.IRQPoll()
+			cpu.Tasklet = cpuF.Tasklet()
+			cpu.Sched = cpuF.Sched()
+			cpu.HRTimer = cpuF.HRTimer()
+			cpu.RCU = cpuF.RCU()
+		}
+		s.CPU[i] = cpu
+	}
+	return s
+}
+
+// Ticker delivers the system's softirq counts at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,398 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package softirqs handles the processing of the number of softirqs, by type,
+// serviced by each CPU: /proc/softirqs. The counts are aggregated since system
+// boot. Softirqs per second, for each CPU, can be calculated using the
+// RateProfiler or RateTicker.
+package softirqs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/helpers"
+)
+
+const procFile = "/proc/softirqs"
+
+// SoftIRQs holds the softirq counts of each CPU.
+type SoftIRQs struct {
+	Timestamp int64 `json:"timestamp"`
+	CPU       []CPU `json:"cpu"`
+}
+
+// CPU holds the number of softirqs, by type, serviced by a single CPU. ID is
+// the CPU's number.
+type CPU struct {
+	ID      int32 `json:"id"`
+	HI      int64 `json:"hi"`
+	Timer   int64 `json:"timer"`
+	NetTX   int64 `json:"net_tx"`
+	NetRX   int64 `json:"net_rx"`
+	Block   int64 `json:"block"`
+	IRQPoll int64 `json:"irq_poll"`
+	Tasklet int64 `json:"tasklet"`
+	Sched   int64 `json:"sched"`
+	HRTimer int64 `json:"hrtimer"`
+	RCU     int64 `json:"rcu"`
+}
+
+// field returns a pointer to the CPU's field for the softirq type, t. If the
+// type isn't known, nil is returned.
+func (c *CPU) field(t []byte) *int64 {
+	switch string(t) {
+	case "HI":
+		return &c.HI
+	case "TIMER":
+		return &c.Timer
+	case "NET_TX":
+		return &c.NetTX
+	case "NET_RX":
+		return &c.NetRX
+	case "BLOCK", "BLOCK_IOPOLL":
+		return &c.Block
+	case "IRQ_POLL":
+		return &c.IRQPoll
+	case "TASKLET":
+		return &c.Tasklet
+	case "SCHED":
+		return &c.Sched
+	case "HRTIMER":
+		return &c.HRTimer
+	case "RCU":
+		return &c.RCU
+	}
+	return nil
+}
+
+// Profiler is used to process the /proc/softirqs file.
+type Profiler struct {
+	joe.Procer
+	*joe.Buffer
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	proc, err := joe.NewProc(procFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Procer: proc, Buffer: joe.NewBuffer()}, nil
+}
+
+// Reset resources: after reset, the profiler is ready to be used again.
+func (prof *Profiler) Reset() error {
+	prof.Buffer.Reset()
+	return prof.Procer.Reset()
+}
+
+// Get returns the current softirq counts.
+func (prof *Profiler) Get() (s *SoftIRQs, err error) {
+	err = prof.Reset()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		i, pos, line, x int
+		n               uint64
+		f               *int64
+	)
+	s = &SoftIRQs{Timestamp: time.Now().UTC().UnixNano()}
+	for {
+		prof.Line, err = prof.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			if err != bufio.ErrBufferFull {
+				return nil, &joe.ReadError{Err: err}
+			}
+			err = prof.LongLine(prof.Procer)
+			if err != nil {
+				return nil, err
+			}
+		}
+		line++
+		// the first line is the CPU header: CPU0 CPU1 ...
+		if line == 1 {
+			for _, v := range strings.Fields(string(prof.Line)) {
+				n, err = helpers.ParseUint([]byte(strings.TrimPrefix(v, "CPU")))
+				if err != nil {
+					return nil, &joe.ParseError{Info: fmt.Sprintf("line %d: %s", line, v), Err: err}
+				}
+				s.CPU = append(s.CPU, CPU{ID: int32(n)})
+			}
+			continue
+		}
+		prof.Line = joe.TrimLeadingSpaces(prof.Line)
+		// the softirq type is everything up to the ':'
+		for i = 0; i < len(prof.Line); i++ {
+			if prof.Line[i] == ':' {
+				break
+			}
+		}
+		prof.Val = prof.Line[:i]
+		pos = i + 1
+		// one count per CPU
+		for x = 0; x < len(s.CPU) && pos < len(prof.Line); x++ {
+			for pos < len(prof.Line) && prof.Line[pos] == 0x20 {
+				pos++
+			}
+			for i = pos; i < len(prof.Line); i++ {
+				if prof.Line[i] == 0x20 || prof.Line[i] == '\n' {
+					break
+				}
+			}
+			if i == pos {
+				break
+			}
+			n, err = helpers.ParseUint(prof.Line[pos:i])
+			if err != nil {
+				return nil, &joe.ParseError{Info: fmt.Sprintf("line %d: %s: field %d", line, prof.Val, x+1), Err: err}
+			}
+			pos = i
+			f = s.CPU[x].field(prof.Val)
+			if f == nil { // unknown softirq type; skip the line
+				break
+			}
+			*f = int64(n)
+		}
+	}
+	return s, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the current softirq counts using the package's global
+// Profiler.
+func Get() (s *SoftIRQs, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Ticker delivers the system's softirq counts at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *SoftIRQs
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *SoftIRQs), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	// ticker
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- s
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
+
+// Rates holds the number of softirqs per second, by type, of each CPU. The
+// TimeDelta field holds the time elapsed, in nanoseconds, between the two
+// snapshots used to calculate the rates.
+type Rates struct {
+	Timestamp int64     `json:"timestamp"`
+	TimeDelta int64     `json:"time_delta"`
+	CPU       []CPURate `json:"cpu"`
+}
+
+// CPURate holds the number of softirqs per second, by type, serviced by a
+// single CPU. ID is the CPU's number.
+type CPURate struct {
+	ID      int32   `json:"id"`
+	HI      float32 `json:"hi"`
+	Timer   float32 `json:"timer"`
+	NetTX   float32 `json:"net_tx"`
+	NetRX   float32 `json:"net_rx"`
+	Block   float32 `json:"block"`
+	IRQPoll float32 `json:"irq_poll"`
+	Tasklet float32 `json:"tasklet"`
+	Sched   float32 `json:"sched"`
+	HRTimer float32 `json:"hrtimer"`
+	RCU     float32 `json:"rcu"`
+}
+
+// CalculateRates returns the softirqs per second between two /proc/softirqs
+// snapshots. CPUs are matched by ID; a CPU that doesn't exist in the prior
+// snapshot, e.g. a CPU that was brought online between the two snapshots, is
+// not included. Any count that is lower than its prior value is treated as
+// not having changed.
+func CalculateRates(prior, cur *SoftIRQs) *Rates {
+	r := &Rates{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, CPU: make([]CPURate, 0, len(cur.CPU))}
+	if r.TimeDelta <= 0 {
+		return r
+	}
+	secs := float32(r.TimeDelta) / float32(time.Second)
+	for i := 0; i < len(cur.CPU); i++ {
+		// cpus are usually in the same order; only search if they aren't.
+		if i < len(prior.CPU) && prior.CPU[i].ID == cur.CPU[i].ID {
+			r.CPU = append(r.CPU, calculateRate(&prior.CPU[i], &cur.CPU[i], secs))
+			continue
+		}
+		for j := 0; j < len(prior.CPU); j++ {
+			if prior.CPU[j].ID == cur.CPU[i].ID {
+				r.CPU = append(r.CPU, calculateRate(&prior.CPU[j], &cur.CPU[i], secs))
+				break
+			}
+		}
+	}
+	return r
+}
+
+// calculateRate calculates the softirqs per second of a single CPU.
+func calculateRate(prior, cur *CPU, secs float32) CPURate {
+	return CPURate{
+		ID:      cur.ID,
+		HI:      rate(prior.HI, cur.HI, secs),
+		Timer:   rate(prior.Timer, cur.Timer, secs),
+		NetTX:   rate(prior.NetTX, cur.NetTX, secs),
+		NetRX:   rate(prior.NetRX, cur.NetRX, secs),
+		Block:   rate(prior.Block, cur.Block, secs),
+		IRQPoll: rate(prior.IRQPoll, cur.IRQPoll, secs),
+		Tasklet: rate(prior.Tasklet, cur.Tasklet, secs),
+		Sched:   rate(prior.Sched, cur.Sched, secs),
+		HRTimer: rate(prior.HRTimer, cur.HRTimer, secs),
+		RCU:     rate(prior.RCU, cur.RCU, secs),
+	}
+}
+
+// rate returns the per second rate of change between the prior and current
+// values. If the current value is lower than the prior value, 0 is returned.
+func rate(prior, cur int64, secs float32) float32 {
+	if cur < prior {
+		return 0
+	}
+	return float32(cur-prior) / secs
+}
+
+// RateProfiler is used to calculate the softirqs per second; the last
+// /proc/softirqs snapshot is kept so that the rates can be calculated.
+type RateProfiler struct {
+	*Profiler
+	prior *SoftIRQs
+}
+
+// NewRateProfiler returns an initialized RateProfiler; ready to use. The
+// initial /proc/softirqs snapshot is taken during initialization.
+func NewRateProfiler() (prof *RateProfiler, err error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	s, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &RateProfiler{Profiler: p, prior: s}, nil
+}
+
+// Get returns the softirqs per second since the last time the RateProfiler
+// was used. If this is the first use, the rates since the RateProfiler was
+// created are returned.
+func (prof *RateProfiler) Get() (r *Rates, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	r = CalculateRates(prof.prior, cur)
+	prof.prior = cur
+	return r, nil
+}
+
+// RateTicker delivers the system's softirqs per second at intervals.
+type RateTicker struct {
+	*joe.Ticker
+	Data chan *Rates
+	*RateProfiler
+}
+
+// NewRateTicker returns a new RateTicker containing a Data channel that
+// delivers the data at intervals and an error channel that delivers any
+// errors encountered. Stop the ticker to signal the ticker to stop running.
+// Stopping the ticker does not close the Data channel; call Close to close
+// both the ticker and the data channel.
+func NewRateTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewRateProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := RateTicker{Ticker: joe.NewTicker(d), Data: make(chan *Rates), RateProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *RateTicker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- r
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *RateTicker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
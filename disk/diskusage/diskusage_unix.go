@@ -0,0 +1,208 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diskusage calculates IO usage of the block devices. Usage is
+// calculated by taking the difference between two /proc/diskstats snapshots
+// and deriving per second rates, average wait times, average queue depth, and
+// utilization, similar to what iostat reports.
+package diskusage
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	stats "github.com/c3sr/joefriday/disk/diskstats"
+	"github.com/c3sr/joefriday/disk/structs"
+)
+
+// SectorSize is the size, in bytes, of the sectors reported by
+// /proc/diskstats. The kernel always reports sectors as 512 bytes, regardless
+// of the device's actual sector size.
+const SectorSize = 512
+
+// Profiler is used to process the IO usage of the block devices; the last
+// /proc/diskstats snapshot is kept so that usage can be calculated.
+type Profiler struct {
+	*stats.Profiler
+	prior *structs.DiskStats
+}
+
+// Returns an initialized Profiler; ready to use. The initial /proc/diskstats
+// snapshot is taken during initialization.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := stats.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	s, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, prior: s}, nil
+}
+
+// Get returns the IO usage of the block devices since the last time the
+// Profiler was used. If this is the first use, the usage since the Profiler
+// was created is returned.
+func (prof *Profiler) Get() (u *structs.DiskUsage, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	u = Calculate(prof.prior, cur)
+	prof.prior = cur
+	return u, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the IO usage of the block devices using the package's global
+// Profiler. The usage is calculated from the delta between this call and the
+// prior one; on the first call the global Profiler is created and the usage
+// is calculated using the snapshot taken during its creation.
+func Get() (u *structs.DiskUsage, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Calculate returns the IO usage between two /proc/diskstats snapshots.
+// Devices are matched by their major and minor numbers; a device that doesn't
+// exist in the prior snapshot, e.g. a device that was attached between the
+// two snapshots, is not included. Any counter that is lower than its prior
+// value is treated as not having changed.
+func Calculate(prior, cur *structs.DiskStats) *structs.DiskUsage {
+	u := &structs.DiskUsage{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, Device: make([]structs.Usage, 0, len(cur.Device))}
+	if u.TimeDelta <= 0 {
+		return u
+	}
+	for i := 0; i < len(cur.Device); i++ {
+		// devices are usually in the same order; only search if they aren't.
+		if i < len(prior.Device) && sameDevice(&prior.Device[i], &cur.Device[i]) {
+			u.Device = append(u.Device, calculateUsage(&prior.Device[i], &cur.Device[i], u.TimeDelta))
+			continue
+		}
+		for j := 0; j < len(prior.Device); j++ {
+			if sameDevice(&prior.Device[j], &cur.Device[i]) {
+				u.Device = append(u.Device, calculateUsage(&prior.Device[j], &cur.Device[i], u.TimeDelta))
+				break
+			}
+		}
+	}
+	return u
+}
+
+// sameDevice returns whether or not the two entries are for the same device.
+func sameDevice(a, b *structs.Device) bool {
+	return a.Major == b.Major && a.Minor == b.Minor
+}
+
+// calculateUsage calculates the usage of a single device. The delta, d, is in
+// nanoseconds.
+func calculateUsage(prior, cur *structs.Device, d int64) structs.Usage {
+	u := structs.Usage{Major: cur.Major, Minor: cur.Minor, Name: cur.Name, IOInProgress: cur.IOInProgress}
+	secs := float32(d) / float32(time.Second)
+	ms := float32(d) / float32(time.Millisecond)
+
+	reads := delta(prior.ReadsCompleted, cur.ReadsCompleted)
+	writes := delta(prior.WritesCompleted, cur.WritesCompleted)
+	readingTime := delta(prior.ReadingTime, cur.ReadingTime)
+	writingTime := delta(prior.WritingTime, cur.WritingTime)
+
+	u.ReadsPerSec = float32(reads) / secs
+	u.ReadsMergedPerSec = float32(delta(prior.ReadsMerged, cur.ReadsMerged)) / secs
+	u.ReadBytesPerSec = float32(delta(prior.ReadSectors, cur.ReadSectors)*SectorSize) / secs
+	u.WritesPerSec = float32(writes) / secs
+	u.WritesMergedPerSec = float32(delta(prior.WritesMerged, cur.WritesMerged)) / secs
+	u.WriteBytesPerSec = float32(delta(prior.WrittenSectors, cur.WrittenSectors)*SectorSize) / secs
+	if reads > 0 {
+		u.ReadAwait = float32(readingTime) / float32(reads)
+	}
+	if writes > 0 {
+		u.WriteAwait = float32(writingTime) / float32(writes)
+	}
+	if reads+writes > 0 {
+		u.Await = float32(readingTime+writingTime) / float32(reads+writes)
+	}
+	// the IO times are in milliseconds.
+	u.QueueDepth = float32(delta(prior.WeightedIOTime, cur.WeightedIOTime)) / ms
+	u.Util = float32(delta(prior.IOTime, cur.IOTime)) / ms * 100
+	if u.Util > 100 {
+		u.Util = 100
+	}
+	return u
+}
+
+// delta returns the difference between the current and prior values. If the
+// current value is lower than the prior value, e.g. the counter was reset or
+// wrapped, 0 is returned.
+func delta(prior, cur uint64) uint64 {
+	if cur < prior {
+		return 0
+	}
+	return cur - prior
+}
+
+// Ticker delivers the IO usage of the block devices at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *structs.DiskUsage
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *structs.DiskUsage), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	// ticker
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			u, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- u
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,219 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diskusage calculates IO usage of the block devices. Usage is
+// calculated by taking the difference between two /proc/diskstats snapshots.
+// Instead of returning a Go struct, it returns Flatbuffer serialized bytes. A
+// function to deserialize the Flatbuffer serialized bytes into a
+// structs.DiskUsage struct is provided.
+//
+// Note: the package name is diskusage and not the final element of the import
+// path (flat).
+package diskusage
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	usage "github.com/c3sr/joefriday/disk/diskusage"
+	"github.com/c3sr/joefriday/disk/structs"
+	"github.com/c3sr/joefriday/disk/structs/flat"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the IO usage of the block devices as
+// Flatbuffer serialized bytes.
+type Profiler struct {
+	*usage.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized Profiler that uses Flatbuffers.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := usage.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the IO usage of the block devices since the last time the
+// Profiler was used as Flatbuffer serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	u, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(u), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the IO usage of the block devices as Flatbuffer serialized
+// bytes using the package's global Profiler. On the first call the global
+// Profiler is created and the usage is calculated using the snapshot taken
+// during its creation.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+
+	return std.Get()
+}
+
+// Serialize serializes structs.DiskUsage as Flatbuffer serialized bytes.
+func (prof *Profiler) Serialize(u *structs.DiskUsage) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	devF := make([]fb.UOffsetT, len(u.Device))
+	names := make([]fb.UOffsetT, len(u.Device))
+	for i := 0; i < len(names); i++ {
+		names[i] = prof.Builder.CreateString(u.Device[i].Name)
+	}
+	for i := 0; i < len(devF); i++ {
+		flat.UsageStart(prof.Builder)
+		flat.UsageAddMajor(prof.Builder, u.Device[i].Major)
+		flat.UsageAddMinor(prof.Builder, u.Device[i].Minor)
+		flat.UsageAddName(prof.Builder, names[i])
+		flat.UsageAddReadsPerSec(prof.Builder, u.Device[i].ReadsPerSec)
+		flat.UsageAddReadsMergedPerSec(prof.Builder, u.Device[i].ReadsMergedPerSec)
+		flat.UsageAddReadBytesPerSec(prof.Builder, u.Device[i].ReadBytesPerSec)
+		flat.UsageAddReadAwait(prof.Builder, u.Device[i].ReadAwait)
+		flat.UsageAddWritesPerSec(prof.Builder, u.Device[i].WritesPerSec)
+		flat.UsageAddWritesMergedPerSec(prof.Builder, u.Device[i].WritesMergedPerSec)
+		flat.UsageAddWriteBytesPerSec(prof.Builder, u.Device[i].WriteBytesPerSec)
+		flat.UsageAddWriteAwait(prof.Builder, u.Device[i].WriteAwait)
+		flat.UsageAddAwait(prof.Builder, u.Device[i].Await)
+		flat.UsageAddQueueDepth(prof.Builder, u.Device[i].QueueDepth)
+		flat.UsageAddUtil(prof.Builder, u.Device[i].Util)
+		flat.UsageAddIOInProgress(prof.Builder, u.Device[i].IOInProgress)
+		devF[i] = flat.UsageEnd(prof.Builder)
+	}
+	flat.DiskUsageStartDeviceVector(prof.Builder, len(devF))
+	for i := len(devF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(devF[i])
+	}
+	devV := prof.Builder.EndVector(len(devF))
+	flat.DiskUsageStart(prof.Builder)
+	flat.DiskUsageAddTimestamp(prof.Builder, u.Timestamp)
+	flat.DiskUsageAddTimeDelta(prof.Builder, u.TimeDelta)
+	flat.DiskUsageAddDevice(prof.Builder, devV)
+	prof.Builder.Finish(flat.DiskUsageEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// Serialize serializes structs.DiskUsage as Flatbuffer serialized bytes using
+// the package's global Profiler.
+func Serialize(u *structs.DiskUsage) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(u), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserialize's them
+// as a structs.DiskUsage.
+func Deserialize(p []byte) *structs.DiskUsage {
+	u := &structs.DiskUsage{}
+	devF := &flat.Usage{}
+	usageFlat := flat.GetRootAsDiskUsage(p, 0)
+	u.Timestamp = usageFlat.Timestamp()
+	u.TimeDelta = usageFlat.TimeDelta()
+	len := usageFlat.DeviceLength()
+	u.Device = make([]structs.Usage, len)
+	for i := 0; i < len; i++ {
+		var dev structs.Usage
+		if usageFlat.Device(devF, i) {
+			dev.Major = devF.Major()
+			dev.Minor = devF.Minor()
+			dev.Name = string(devF.Name())
+			dev.ReadsPerSec = devF.ReadsPerSec()
+			dev.ReadsMergedPerSec = devF.ReadsMergedPerSec()
+			dev.ReadBytesPerSec = devF.ReadBytesPerSec()
+			dev.ReadAwait = devF.ReadAwait()
+			dev.WritesPerSec = devF.WritesPerSec()
+			dev.WritesMergedPerSec = devF.WritesMergedPerSec()
+			dev.WriteBytesPerSec = devF.WriteBytesPerSec()
+			dev.WriteAwait = devF.WriteAwait()
+			dev.Await = devF.Await()
+			dev.QueueDepth = devF.QueueDepth()
+			dev.Util = devF.Util()
+			dev.IOInProgress = devF.IOInProgress()
+		}
+		u.Device[i] = dev
+	}
+	return u
+}
+
+// Ticker delivers the IO usage of the block devices at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -37,14 +37,14 @@ func (rcv *DiskUsage) TimeDelta() int64 {
 	return 0
 }
 
-func (rcv *DiskUsage) Device(obj *Device, j int) bool {
+func (rcv *DiskUsage) Device(obj *Usage, j int) bool {
 	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
 	if o != 0 {
 		x := rcv._tab.Vector(o)
 		x += flatbuffers.UOffsetT(j) * 4
 		x = rcv._tab.Indirect(x)
 	if obj == nil {
-		obj = new(Device)
+		obj = new(Usage)
 	}
 		obj.Init(rcv._tab.Bytes, x)
 		return true
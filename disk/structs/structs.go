@@ -38,9 +38,37 @@ type Device struct {
 	WeightedIOTime  uint64 `json:"weighted_io_time"`
 }
 
-// DiskUsage holds the usage information for all of the block devices.
+// DiskUsage holds the usage information for all of the block devices. Usage
+// is calculated as the delta between two /proc/diskstats snapshots; the
+// TimeDelta field holds the time elapsed, in nanoseconds, between the two
+// snapshots used to calculate the usage.
 type DiskUsage struct {
-	Timestamp int64    `json:"timestamp"`
-	TimeDelta int64    `json:"time_delta"`
-	Device   []Device `json:"device"`
+	Timestamp int64   `json:"timestamp"`
+	TimeDelta int64   `json:"time_delta"`
+	Device    []Usage `json:"device"`
+}
+
+// Usage contains the usage information for a given block device. The rates
+// are per second. ReadAwait, WriteAwait, and Await are the average time, in
+// milliseconds, for the requests issued during the interval to be served,
+// including time spent in queue. QueueDepth is the average number of requests
+// in queue or being served. Util is the percentage of the interval during
+// which the device had IO requests in progress. IOInProgress is the number of
+// IO requests in progress at the end of the interval.
+type Usage struct {
+	Major              uint32  `json:"major"`
+	Minor              uint32  `json:"minor"`
+	Name               string  `json:"name"`
+	ReadsPerSec        float32 `json:"reads_per_sec"`
+	ReadsMergedPerSec  float32 `json:"reads_merged_per_sec"`
+	ReadBytesPerSec    float32 `json:"read_bytes_per_sec"`
+	ReadAwait          float32 `json:"read_await"`
+	WritesPerSec       float32 `json:"writes_per_sec"`
+	WritesMergedPerSec float32 `json:"writes_merged_per_sec"`
+	WriteBytesPerSec   float32 `json:"write_bytes_per_sec"`
+	WriteAwait         float32 `json:"write_await"`
+	Await              float32 `json:"await"`
+	QueueDepth         float32 `json:"queue_depth"`
+	Util               float32 `json:"util"`
+	IOInProgress       int32   `json:"io_in_progress"`
 }
@@ -295,6 +295,8 @@ func (p *TempFileProc) Remove() error {
 type Buffer struct {
 	Line []byte
 	Val  []byte
+	// holds lines that are longer than the read buffer; see LongLine.
+	long []byte
 }
 
 // NewBuffer returns an initialized Buffer.
@@ -307,3 +309,22 @@ func (b *Buffer) Reset() {
 	b.Line = b.Line[:0]
 	b.Val = b.Val[:0]
 }
+
+// LongLine reads the rest of a line that didn't fit in r's read buffer, i.e.
+// ReadSlice returned bufio.ErrBufferFull, and sets Line to the entire line:
+// the part that was already read, which is in Line, along with the rest of
+// it. The line is accumulated in a buffer that is re-used between calls.
+func (b *Buffer) LongLine(r Procer) error {
+	b.long = append(b.long[:0], b.Line...)
+	for {
+		p, err := r.ReadSlice('\n')
+		b.long = append(b.long, p...)
+		if err == nil {
+			b.Line = b.long
+			return nil
+		}
+		if err != bufio.ErrBufferFull {
+			return &ReadError{Err: err}
+		}
+	}
+}
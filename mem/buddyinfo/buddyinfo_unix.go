@@ -0,0 +1,419 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package buddyinfo gets the system's free memory by block order,
+// /proc/buddyinfo, and, when it's readable, the free memory by migrate type,
+// /proc/pagetypeinfo. The fragmentation of each zone is calculated from the
+// free blocks.
+//
+// The buddy allocator keeps free memory in blocks of 2^order pages; a high
+// order allocation, e.g. a huge page, needs a free block of at least that
+// order. When memory is fragmented, there may be plenty of free memory but
+// few high order blocks.
+//
+// /proc/pagetypeinfo is usually only readable by root; if it can't be read,
+// only the /proc/buddyinfo information is available.
+package buddyinfo
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/helpers"
+)
+
+const (
+	procBuddyInfo    = "/proc/buddyinfo"
+	procPageTypeInfo = "/proc/pagetypeinfo"
+)
+
+// Info holds the free memory by block order of each zone. PageTypeInfo is
+// whether or not /proc/pagetypeinfo was read; if it wasn't, PageBlockOrder,
+// PagesPerBlock, and each zone's MigrateType won't be populated.
+// PageBlockOrder is the order of the page block, the unit the kernel uses to
+// group pages by migrate type; on most systems, it is also the huge page
+// order.
+type Info struct {
+	Timestamp      int64  `json:"timestamp"`
+	PageTypeInfo   bool   `json:"page_type_info"`
+	PageBlockOrder int32  `json:"page_block_order"`
+	PagesPerBlock  int64  `json:"pages_per_block"`
+	Zone           []Zone `json:"zone"`
+}
+
+// Zone holds the free memory information of a memory zone on a node. Free
+// holds the number of free blocks of each order, starting with order 0; a
+// block of order n is 2^n pages. FreePages is the total number of free pages.
+//
+// FragmentationIndex and UnusableIndex hold, for each order, the indexes the
+// kernel reports in debugfs's extfrag/extfrag_index and
+// extfrag/unusable_index. The fragmentation index is between 0 and 1 when an
+// allocation of that order would fail: towards 0 means the failure is due to
+// a lack of memory and towards 1 means it is due to fragmentation. It is -1
+// when an allocation of that order would succeed and 0 if the zone doesn't
+// have any free memory. The unusable index is the fraction of the free memory
+// that can't be used for an allocation of that order; like the kernel, it is
+// 1 if the zone doesn't have any free memory.
+//
+// MigrateType holds the free memory by migrate type, from
+// /proc/pagetypeinfo; it is nil if /proc/pagetypeinfo wasn't read.
+type Zone struct {
+	Node               int32         `json:"node"`
+	Name               string        `json:"name"`
+	Free               []int64       `json:"free"`
+	FreePages          int64         `json:"free_pages"`
+	FragmentationIndex []float32     `json:"fragmentation_index"`
+	UnusableIndex      []float32     `json:"unusable_index"`
+	MigrateType        []MigrateType `json:"migrate_type"`
+}
+
+// MigrateType holds the free memory of a migrate type within a zone, e.g.
+// Unmovable, Movable, or Reclaimable. Free holds the number of free blocks
+// of each order; newer kernels stop counting at 100000 blocks, in which case
+// the count is 100000. Blocks is the number of page blocks of the migrate
+// type.
+type MigrateType struct {
+	Name   string  `json:"name"`
+	Free   []int64 `json:"free"`
+	Blocks int64   `json:"blocks"`
+}
+
+// FindZone returns the zone with the name on node n. A false will be
+// returned if the zone doesn't exist.
+func (inf *Info) FindZone(n int32, name string) (*Zone, bool) {
+	for i := range inf.Zone {
+		if inf.Zone[i].Node == n && inf.Zone[i].Name == name {
+			return &inf.Zone[i], true
+		}
+	}
+	return nil, false
+}
+
+// Calculate calculates the zone's FreePages, FragmentationIndex, and
+// UnusableIndex from its Free blocks.
+func (z *Zone) Calculate() {
+	var blocks int64
+	z.FreePages = 0
+	for order, n := range z.Free {
+		blocks += n
+		z.FreePages += n << uint(order)
+	}
+	z.FragmentationIndex = make([]float32, len(z.Free))
+	z.UnusableIndex = make([]float32, len(z.Free))
+	for order := range z.Free {
+		// the number of free blocks that can satisfy an allocation of this
+		// order; larger blocks can be split.
+		var suitable int64
+		for o := order; o < len(z.Free); o++ {
+			suitable += z.Free[o] << uint(o-order)
+		}
+		if z.FreePages > 0 {
+			z.UnusableIndex[order] = float32(z.FreePages-suitable<<uint(order)) / float32(z.FreePages)
+		} else {
+			// no free memory is treated as all of it being unusable.
+			z.UnusableIndex[order] = 1
+		}
+		if blocks == 0 {
+			continue
+		}
+		if suitable > 0 {
+			z.FragmentationIndex[order] = -1
+			continue
+		}
+		requested := float64(int64(1) << uint(order))
+		z.FragmentationIndex[order] = float32(1 - (1+float64(z.FreePages)/requested)/float64(blocks))
+	}
+}
+
+// Profiler is used to get the free memory information by processing the
+// /proc/buddyinfo and /proc/pagetypeinfo files.
+type Profiler struct {
+	joe.Procer
+	*joe.Buffer
+	// pageType is nil if /proc/pagetypeinfo isn't readable.
+	pageType joe.Procer
+}
+
+// Returns an initialized Profiler; ready to use. If /proc/pagetypeinfo
+// doesn't exist or isn't readable, e.g. the process isn't running as root,
+// only /proc/buddyinfo will be used.
+func NewProfiler() (prof *Profiler, err error) {
+	proc, err := joe.NewProc(procBuddyInfo)
+	if err != nil {
+		return nil, err
+	}
+	prof = &Profiler{Procer: proc, Buffer: joe.NewBuffer()}
+	pt, err := joe.NewProc(procPageTypeInfo)
+	if err != nil {
+		if !os.IsNotExist(err) && !os.IsPermission(err) {
+			return nil, err
+		}
+		return prof, nil
+	}
+	prof.pageType = pt
+	return prof, nil
+}
+
+// Reset resources: after reset, the profiler is ready to be used again.
+func (prof *Profiler) Reset() error {
+	prof.Buffer.Reset()
+	if prof.pageType != nil {
+		err := prof.pageType.Reset()
+		if err != nil {
+			return err
+		}
+	}
+	return prof.Procer.Reset()
+}
+
+// PageTypeInfo returns whether or not /proc/pagetypeinfo is being used.
+func (prof *Profiler) PageTypeInfo() bool {
+	return prof.pageType != nil
+}
+
+// Get returns the current free memory information.
+func (prof *Profiler) Get() (inf *Info, err error) {
+	err = prof.Reset()
+	if err != nil {
+		return nil, err
+	}
+	inf = &Info{Timestamp: time.Now().UTC().UnixNano()}
+	for {
+		prof.Line, err = prof.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return inf, &joe.ReadError{Err: err}
+		}
+		// Node 0, zone   Normal   3224   1023     84 ...
+		fields := bytes.Fields(prof.Line)
+		if len(fields) < 4 {
+			continue
+		}
+		var z Zone
+		z.Node, err = parseNode(fields[1])
+		if err != nil {
+			return inf, err
+		}
+		z.Name = string(fields[3])
+		z.Free, err = parseCounts(fields[4:])
+		if err != nil {
+			return inf, &joe.ParseError{Info: procBuddyInfo + ": " + z.Name, Err: err}
+		}
+		z.Calculate()
+		inf.Zone = append(inf.Zone, z)
+	}
+	if prof.pageType == nil {
+		return inf, nil
+	}
+	err = prof.getPageTypeInfo(inf)
+	if err != nil {
+		return inf, err
+	}
+	inf.PageTypeInfo = true
+	return inf, nil
+}
+
+// getPageTypeInfo processes /proc/pagetypeinfo and adds its information to
+// inf's zones.
+func (prof *Profiler) getPageTypeInfo(inf *Info) error {
+	var (
+		blockTypes [][]byte // the migrate type names of the block counts
+		section    int      // 0: header, 1: free pages, 2: block counts, 3: other
+	)
+	for {
+		line, err := prof.pageType.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return &joe.ReadError{Err: err}
+		}
+		fields := bytes.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if !bytes.Equal(fields[0], []byte("Node")) {
+			switch {
+			case bytes.HasPrefix(line, []byte("Page block order:")):
+				n, err := helpers.ParseUint(fields[len(fields)-1])
+				if err != nil {
+					return &joe.ParseError{Info: procPageTypeInfo + ": page block order", Err: err}
+				}
+				inf.PageBlockOrder = int32(n)
+			case bytes.HasPrefix(line, []byte("Pages per block:")):
+				n, err := helpers.ParseUint(fields[len(fields)-1])
+				if err != nil {
+					return &joe.ParseError{Info: procPageTypeInfo + ": pages per block", Err: err}
+				}
+				inf.PagesPerBlock = int64(n)
+			case bytes.HasPrefix(line, []byte("Free pages count per migrate type")):
+				section = 1
+			case bytes.HasPrefix(line, []byte("Number of blocks type")):
+				section = 2
+				blockTypes = fields[4:]
+				// the field slices reference the buffer, which is reused.
+				for i := range blockTypes {
+					blockTypes[i] = append([]byte(nil), blockTypes[i]...)
+				}
+			default:
+				// e.g. Number of mixed blocks, which requires page owner
+				// tracking.
+				section = 3
+			}
+			continue
+		}
+		if len(fields) < 4 {
+			continue
+		}
+		n, err := parseNode(fields[1])
+		if err != nil {
+			return err
+		}
+		z, ok := inf.FindZone(n, string(bytes.TrimSuffix(fields[3], []byte(","))))
+		if !ok {
+			continue
+		}
+		switch section {
+		case 1:
+			// Node    0, zone   Normal, type      Movable   3192    963 ...
+			if len(fields) < 6 {
+				continue
+			}
+			free, err := parseCounts(fields[6:])
+			if err != nil {
+				return &joe.ParseError{Info: procPageTypeInfo + ": " + z.Name, Err: err}
+			}
+			z.MigrateType = append(z.MigrateType, MigrateType{Name: string(fields[5]), Free: free})
+		case 2:
+			// Node 0, zone   Normal           57          625 ...
+			counts, err := parseCounts(fields[4:])
+			if err != nil {
+				return &joe.ParseError{Info: procPageTypeInfo + ": " + z.Name, Err: err}
+			}
+			for i, cnt := range counts {
+				if i >= len(blockTypes) {
+					break
+				}
+				name := string(blockTypes[i])
+				m := z.migrateType(name)
+				if m == nil {
+					z.MigrateType = append(z.MigrateType, MigrateType{Name: name})
+					m = &z.MigrateType[len(z.MigrateType)-1]
+				}
+				m.Blocks = cnt
+			}
+		}
+	}
+}
+
+// migrateType returns the zone's migrate type with the name; nil if it
+// doesn't exist.
+func (z *Zone) migrateType(name string) *MigrateType {
+	for i := range z.MigrateType {
+		if z.MigrateType[i].Name == name {
+			return &z.MigrateType[i]
+		}
+	}
+	return nil
+}
+
+// parseNode parses a node number, e.g. "0,".
+func parseNode(v []byte) (int32, error) {
+	n, err := helpers.ParseUint(bytes.TrimSuffix(v, []byte(",")))
+	if err != nil {
+		return 0, &joe.ParseError{Info: "node", Err: err}
+	}
+	return int32(n), nil
+}
+
+// parseCounts parses a list of block counts. A count that starts with a '>'
+// is a lower bound, e.g. >100000; the bound is used as the count.
+func parseCounts(fields [][]byte) ([]int64, error) {
+	counts := make([]int64, len(fields))
+	for i, v := range fields {
+		n, err := helpers.ParseUint(bytes.TrimPrefix(v, []byte(">")))
+		if err != nil {
+			return nil, err
+		}
+		counts[i] = int64(n)
+	}
+	return counts, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current free memory information using the package's global
+// Profiler.
+func Get() (inf *Info, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Ticker delivers the system's free memory information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *Info
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *Info), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			inf, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- inf
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,274 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package buddyinfo gets the system's free memory by block order,
+// /proc/buddyinfo, and, when it's readable, /proc/pagetypeinfo. Instead of
+// returning a Go struct, it returns Flatbuffer serialized bytes. A function to
+// deserialize the Flatbuffer serialized bytes into a buddyinfo.Info struct is
+// provided.
+//
+// Note: the package name is buddyinfo and not the final element of the import
+// path (flat).
+package buddyinfo
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	buddy "github.com/c3sr/joefriday/mem/buddyinfo"
+	"github.com/c3sr/joefriday/mem/buddyinfo/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the free memory information, /proc/buddyinfo
+// and /proc/pagetypeinfo, using Flatbuffers.
+type Profiler struct {
+	*buddy.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := buddy.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the current free memory information as Flatbuffer serialized
+// bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	inf, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(inf), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current free memory information as Flatbuffer serialized
+// bytes using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+	return std.Get()
+}
+
+// Serialize serializes buddyinfo.Info using Flatbuffers.
+func (prof *Profiler) Serialize(inf *buddy.Info) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	zonesF := make([]fb.UOffsetT, len(inf.Zone))
+	for i := range inf.Zone {
+		zonesF[i] = prof.SerializeZone(&inf.Zone[i])
+	}
+	structs.InfoStartZoneVector(prof.Builder, len(zonesF))
+	for i := len(zonesF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(zonesF[i])
+	}
+	zonesV := prof.Builder.EndVector(len(zonesF))
+	structs.InfoStart(prof.Builder)
+	structs.InfoAddTimestamp(prof.Builder, inf.Timestamp)
+	structs.InfoAddPageTypeInfo(prof.Builder, inf.PageTypeInfo)
+	structs.InfoAddPageBlockOrder(prof.Builder, inf.PageBlockOrder)
+	structs.InfoAddPagesPerBlock(prof.Builder, inf.PagesPerBlock)
+	structs.InfoAddZone(prof.Builder, zonesV)
+	prof.Builder.Finish(structs.InfoEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// SerializeZone serializes a Zone using Flatbuffers and returns the resulting
+// UOffsetT.
+func (prof *Profiler) SerializeZone(z *buddy.Zone) fb.UOffsetT {
+	name := prof.Builder.CreateString(z.Name)
+	free := prof.serializeCounts(z.Free)
+	frag := prof.serializeIndex(z.FragmentationIndex)
+	unusable := prof.serializeIndex(z.UnusableIndex)
+	migrateF := make([]fb.UOffsetT, len(z.MigrateType))
+	for i := range z.MigrateType {
+		migrateF[i] = prof.SerializeMigrateType(&z.MigrateType[i])
+	}
+	structs.ZoneStartMigrateTypeVector(prof.Builder, len(migrateF))
+	for i := len(migrateF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(migrateF[i])
+	}
+	migrateV := prof.Builder.EndVector(len(migrateF))
+	structs.ZoneStart(prof.Builder)
+	structs.ZoneAddNode(prof.Builder, z.Node)
+	structs.ZoneAddName(prof.Builder, name)
+	structs.ZoneAddFree(prof.Builder, free)
+	structs.ZoneAddFreePages(prof.Builder, z.FreePages)
+	structs.ZoneAddFragmentationIndex(prof.Builder, frag)
+	structs.ZoneAddUnusableIndex(prof.Builder, unusable)
+	structs.ZoneAddMigrateType(prof.Builder, migrateV)
+	return structs.ZoneEnd(prof.Builder)
+}
+
+// SerializeMigrateType serializes a MigrateType using Flatbuffers and returns
+// the resulting UOffsetT.
+func (prof *Profiler) SerializeMigrateType(m *buddy.MigrateType) fb.UOffsetT {
+	name := prof.Builder.CreateString(m.Name)
+	free := prof.serializeCounts(m.Free)
+	structs.MigrateTypeStart(prof.Builder)
+	structs.MigrateTypeAddName(prof.Builder, name)
+	structs.MigrateTypeAddFree(prof.Builder, free)
+	structs.MigrateTypeAddBlocks(prof.Builder, m.Blocks)
+	return structs.MigrateTypeEnd(prof.Builder)
+}
+
+// serializeCounts serializes a vector of block counts. All of the count
+// vectors have the same layout, so the Zone's vector start function is used.
+func (prof *Profiler) serializeCounts(v []int64) fb.UOffsetT {
+	structs.ZoneStartFreeVector(prof.Builder, len(v))
+	for i := len(v) - 1; i >= 0; i-- {
+		prof.Builder.PrependInt64(v[i])
+	}
+	return prof.Builder.EndVector(len(v))
+}
+
+// serializeIndex serializes a vector of per order indexes.
+func (prof *Profiler) serializeIndex(v []float32) fb.UOffsetT {
+	structs.ZoneStartFragmentationIndexVector(prof.Builder, len(v))
+	for i := len(v) - 1; i >= 0; i-- {
+		prof.Builder.PrependFloat32(v[i])
+	}
+	return prof.Builder.EndVector(len(v))
+}
+
+// Serialize serializes buddyinfo.Info using Flatbuffers with the package's
+// global Profiler.
+func Serialize(inf *buddy.Info) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(inf), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// buddyinfo.Info.
+func Deserialize(p []byte) *buddy.Info {
+	infF := structs.GetRootAsInfo(p, 0)
+	inf := &buddy.Info{
+		Timestamp:      infF.Timestamp(),
+		PageTypeInfo:   infF.PageTypeInfo(),
+		PageBlockOrder: infF.PageBlockOrder(),
+		PagesPerBlock:  infF.PagesPerBlock(),
+		Zone:           make([]buddy.Zone, infF.ZoneLength()),
+	}
+	zoneF := &structs.Zone{}
+	migrateF := &structs.MigrateType{}
+	for i := range inf.Zone {
+		if !infF.Zone(zoneF, i) {
+			continue
+		}
+		z := &inf.Zone[i]
+		z.Node = zoneF.Node()
+		z.Name = string(zoneF.Name())
+		z.Free = make([]int64, zoneF.FreeLength())
+		for j := range z.Free {
+			z.Free[j] = zoneF.Free(j)
+		}
+		z.FreePages = zoneF.FreePages()
+		z.FragmentationIndex = make([]float32, zoneF.FragmentationIndexLength())
+		for j := range z.FragmentationIndex {
+			z.FragmentationIndex[j] = zoneF.FragmentationIndex(j)
+		}
+		z.UnusableIndex = make([]float32, zoneF.UnusableIndexLength())
+		for j := range z.UnusableIndex {
+			z.UnusableIndex[j] = zoneF.UnusableIndex(j)
+		}
+		if zoneF.MigrateTypeLength() == 0 {
+			continue
+		}
+		z.MigrateType = make([]buddy.MigrateType, zoneF.MigrateTypeLength())
+		for j := range z.MigrateType {
+			if !zoneF.MigrateType(migrateF, j) {
+				continue
+			}
+			m := &z.MigrateType[j]
+			m.Name = string(migrateF.Name())
+			m.Free = make([]int64, migrateF.FreeLength())
+			for k := range m.Free {
+				m.Free[k] = migrateF.Free(k)
+			}
+			m.Blocks = migrateF.Blocks()
+		}
+	}
+	return inf
+}
+
+// Ticker delivers the system's free memory information at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
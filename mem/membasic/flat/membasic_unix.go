@@ -22,13 +22,11 @@
 package membasic
 
 import (
-	"io"
 	"sync"
 	"time"
 
 	fb "github.com/google/flatbuffers/go"
 	joe "github.com/c3sr/joefriday"
-	"github.com/c3sr/joefriday/helpers"
 	basic "github.com/c3sr/joefriday/mem/membasic"
 	"github.com/c3sr/joefriday/mem/membasic/flat/structs"
 )
@@ -91,6 +89,12 @@ func (prof *Profiler) Serialize(inf *basic.Info) []byte {
 	structs.InfoAddSwapCached(prof.Builder, inf.SwapCached)
 	structs.InfoAddSwapFree(prof.Builder, inf.SwapFree)
 	structs.InfoAddSwapTotal(prof.Builder, inf.SwapTotal)
+	structs.InfoAddBuffers(prof.Builder, inf.Buffers)
+	structs.InfoAddCached(prof.Builder, inf.Cached)
+	structs.InfoAddCommitLimit(prof.Builder, inf.CommitLimit)
+	structs.InfoAddCommittedAS(prof.Builder, inf.CommittedAS)
+	structs.InfoAddShmem(prof.Builder, inf.Shmem)
+	structs.InfoAddSReclaimable(prof.Builder, inf.SReclaimable)
 	prof.Builder.Finish(structs.InfoEnd(prof.Builder))
 	p := prof.Builder.Bytes[prof.Builder.Head():]
 	// copy them (otherwise gets lost in reset)
@@ -128,6 +132,14 @@ func Deserialize(p []byte) *basic.Info {
 	info.SwapCached = infoFlat.SwapCached()
 	info.SwapFree = infoFlat.SwapFree()
 	info.SwapTotal = infoFlat.SwapTotal()
+	info.Buffers = infoFlat.Buffers()
+	info.Cached = infoFlat.Cached()
+	info.CommitLimit = infoFlat.CommitLimit()
+	info.CommittedAS = infoFlat.CommittedAS()
+	info.Shmem = infoFlat.Shmem()
+	info.SReclaimable = infoFlat.SReclaimable()
+	// the derived metrics aren't serialized.
+	info.Derived = info.Derive()
 	return info
 }
 
@@ -155,118 +167,17 @@ func NewTicker(d time.Duration) (joe.Tocker, error) {
 
 // Run runs the ticker.
 func (t *Ticker) Run() {
-	// predeclare some vars
-	var (
-		i, pos, nameLen int
-		v               byte
-		n               uint64
-		err             error
-	)
-	// ticker
-Tick:
 	for {
 		select {
 		case <-t.Done:
 			return
 		case <-t.C:
-			t.Builder.Reset()
-			err = t.Profiler.Profiler.Reset()
+			p, err := t.Get()
 			if err != nil {
 				t.Errs <- err
 				continue
 			}
-			structs.InfoStart(t.Builder)
-			structs.InfoAddTimestamp(t.Builder, time.Now().UTC().UnixNano())
-			for {
-				t.Val = t.Val[:0]
-				t.Line, err = t.ReadSlice('\n')
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					// An error results in sending error message and stop processing of this tick.
-					t.Errs <- &joe.ReadError{Err: err}
-					continue Tick
-				}
-				// first grab the key name (everything up to the ':')
-				for i, v = range t.Line {
-					if v == 0x3A {
-						t.Val = t.Line[:i]
-						pos = i + 1 // skip the :
-						break
-					}
-				}
-				nameLen = len(t.Val)
-				// skip all spaces
-				for i, v = range t.Line[pos:] {
-					if v != 0x20 {
-						pos += i
-						break
-					}
-				}
-
-				// grab the numbers
-				for _, v = range t.Line[pos:] {
-					if v == 0x20 || v == '\n' {
-						break
-					}
-					t.Val = append(t.Val, v)
-				}
-				// any conversion error results in 0
-				n, err = helpers.ParseUint(t.Val[nameLen:])
-				if err != nil {
-					t.Errs <- &joe.ParseError{Info: string(t.Val[:nameLen]), Err: err}
-					continue
-				}
-				v = t.Val[0]
-				if v == 'A' {
-					if t.Val[5] == 'e' && nameLen == 6 {
-						structs.InfoAddActive(t.Builder, n)
-					}
-					continue
-				}
-				if v == 'I' {
-					if nameLen == 8 {
-						structs.InfoAddInactive(t.Builder, n)
-					}
-					continue
-				}
-				if v == 'M' {
-					v = t.Val[3]
-					if nameLen < 8 {
-						if v == 'p' {
-							structs.InfoAddMapped(t.Builder, n)
-							continue
-						}
-						if v == 'F' {
-							structs.InfoAddMemFree(t.Builder, n)
-						}
-						continue
-					}
-					if v == 'A' {
-						structs.InfoAddMemAvailable(t.Builder, n)
-						continue
-					}
-					structs.InfoAddMemTotal(t.Builder, n)
-					continue
-				}
-				if v == 'S' {
-					v = t.Val[1]
-					if v == 'w' {
-						if t.Val[4] == 'C' {
-							structs.InfoAddSwapCached(t.Builder, n)
-							continue
-						}
-						if t.Val[4] == 'F' {
-							structs.InfoAddSwapFree(t.Builder, n)
-							continue
-						}
-						structs.InfoAddSwapTotal(t.Builder, n)
-					}
-				}
-			}
-			t.Builder.Finish(structs.InfoEnd(t.Builder))
-			t.Data <- t.Profiler.Builder.Bytes[t.Builder.Head():]
+			t.Data <- p
 		}
 	}
 }
@@ -101,7 +101,55 @@ func (rcv *Info) SwapTotal() uint64 {
 	return 0
 }
 
-func InfoStart(builder *flatbuffers.Builder) { builder.StartObject(10) }
+func (rcv *Info) Buffers() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(24))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Cached() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(26))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) CommitLimit() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(28))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) CommittedAS() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(30))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Shmem() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(32))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) SReclaimable() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(34))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func InfoStart(builder *flatbuffers.Builder) { builder.StartObject(16) }
 func InfoAddTimestamp(builder *flatbuffers.Builder, Timestamp int64) { builder.PrependInt64Slot(0, Timestamp, 0) }
 func InfoAddActive(builder *flatbuffers.Builder, Active uint64) { builder.PrependUint64Slot(1, Active, 0) }
 func InfoAddInactive(builder *flatbuffers.Builder, Inactive uint64) { builder.PrependUint64Slot(2, Inactive, 0) }
@@ -112,4 +160,10 @@ func InfoAddMemTotal(builder *flatbuffers.Builder, MemTotal uint64) { builder.Pr
 func InfoAddSwapCached(builder *flatbuffers.Builder, SwapCached uint64) { builder.PrependUint64Slot(7, SwapCached, 0) }
 func InfoAddSwapFree(builder *flatbuffers.Builder, SwapFree uint64) { builder.PrependUint64Slot(8, SwapFree, 0) }
 func InfoAddSwapTotal(builder *flatbuffers.Builder, SwapTotal uint64) { builder.PrependUint64Slot(9, SwapTotal, 0) }
+func InfoAddBuffers(builder *flatbuffers.Builder, Buffers uint64) { builder.PrependUint64Slot(10, Buffers, 0) }
+func InfoAddCached(builder *flatbuffers.Builder, Cached uint64) { builder.PrependUint64Slot(11, Cached, 0) }
+func InfoAddCommitLimit(builder *flatbuffers.Builder, CommitLimit uint64) { builder.PrependUint64Slot(12, CommitLimit, 0) }
+func InfoAddCommittedAS(builder *flatbuffers.Builder, CommittedAS uint64) { builder.PrependUint64Slot(13, CommittedAS, 0) }
+func InfoAddShmem(builder *flatbuffers.Builder, Shmem uint64) { builder.PrependUint64Slot(14, Shmem, 0) }
+func InfoAddSReclaimable(builder *flatbuffers.Builder, SReclaimable uint64) { builder.PrependUint64Slot(15, SReclaimable, 0) }
 func InfoEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT { return builder.EndObject() }
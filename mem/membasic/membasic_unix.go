@@ -22,22 +22,51 @@ import (
 
 	"github.com/c3sr/joefriday/helpers"
 	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/mem/meminfo"
 )
 
 const procFile = "/proc/meminfo"
 
-// Info holds the basic meminfo information.
+// Info holds the basic meminfo information. Derived holds the memory metrics,
+// in bytes, that are derived from the other fields, using the same formulas
+// as procps-ng's free; see meminfo.Derived.
 type Info struct {
 	Timestamp    int64  `json:"timestamp"`
 	Active       uint64 `json:"active"`
+	Buffers      uint64 `json:"buffers"`
+	Cached       uint64 `json:"cached"`
+	CommitLimit  uint64 `json:"commit_limit"`
+	CommittedAS  uint64 `json:"commited_as"`
 	Inactive     uint64 `json:"inactive"`
 	Mapped       uint64 `json:"mapped"`
 	MemAvailable uint64 `json:"mem_available"`
 	MemFree      uint64 `json:"mem_free"`
 	MemTotal     uint64 `json:"mem_total"`
+	Shmem        uint64 `json:"shmem"`
+	SReclaimable uint64 `json:"s_reclaimable"`
 	SwapCached   uint64 `json:"swap_cached"`
 	SwapFree     uint64 `json:"swap_free"`
 	SwapTotal    uint64 `json:"swap_total"`
+
+	Derived meminfo.Derived `json:"derived"`
+}
+
+// Derive returns the derived memory metrics for the Info.
+func (inf *Info) Derive() meminfo.Derived {
+	m := meminfo.Info{
+		Buffers:      inf.Buffers,
+		Cached:       inf.Cached,
+		CommitLimit:  inf.CommitLimit,
+		CommittedAS:  inf.CommittedAS,
+		MemAvailable: inf.MemAvailable,
+		MemFree:      inf.MemFree,
+		MemTotal:     inf.MemTotal,
+		Shmem:        inf.Shmem,
+		SReclaimable: inf.SReclaimable,
+		SwapFree:     inf.SwapFree,
+		SwapTotal:    inf.SwapTotal,
+	}
+	return m.Derive()
 }
 
 // Profiler is used to get the basic memory information by processing the
@@ -123,6 +152,27 @@ func (prof *Profiler) Get() (inf *Info, err error) {
 			}
 			continue
 		}
+		if v == 'B' {
+			// Buffers; not Balloon or Bounce
+			if nameLen == 7 && prof.Val[1] == 'u' {
+				inf.Buffers = n
+			}
+			continue
+		}
+		if v == 'C' {
+			if nameLen == 6 {
+				inf.Cached = n
+				continue
+			}
+			if nameLen == 11 && prof.Val[1] == 'o' {
+				inf.CommitLimit = n
+				continue
+			}
+			if nameLen == 12 {
+				inf.CommittedAS = n
+			}
+			continue
+		}
 		if v == 'I' {
 			if nameLen == 8 {
 				inf.Inactive = n
@@ -162,8 +212,17 @@ func (prof *Profiler) Get() (inf *Info, err error) {
 				inf.SwapTotal = n
 				continue
 			}
+			// Shmem; not ShmemHugePages or ShmemPmdMapped
+			if v == 'h' && nameLen == 5 {
+				inf.Shmem = n
+				continue
+			}
+			if v == 'R' {
+				inf.SReclaimable = n
+			}
 		}
 	}
+	inf.Derived = inf.Derive()
 	return inf, nil
 }
 
@@ -208,116 +267,17 @@ func NewTicker(d time.Duration) (joe.Tocker, error) {
 
 // Run runs the ticker.
 func (t *Ticker) Run() {
-	// predeclare some vars
-	var (
-		i, pos, nameLen int
-		v               byte
-		n               uint64
-		err             error
-		inf             Info
-	)
-	// ticker
 	for {
 		select {
 		case <-t.Done:
 			return
 		case <-t.C:
-			err = t.Profiler.Reset()
+			inf, err := t.Get()
 			if err != nil {
 				t.Errs <- err
 				continue
 			}
-			inf.Timestamp = time.Now().UTC().UnixNano()
-			for {
-				t.Val = t.Val[:0]
-				t.Line, err = t.ReadSlice('\n')
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					t.Errs <- &joe.ReadError{Err: err}
-				}
-				// first grab the key name (everything up to the ':')
-				for i, v = range t.Line {
-					if v == ':' {
-						pos = i + 1
-						break
-					}
-					t.Val = append(t.Val, v)
-				}
-				nameLen = len(t.Val)
-
-				// skip all spaces
-				for i, v = range t.Line[pos:] {
-					if v != ' ' {
-						pos += i
-						break
-					}
-				}
-
-				// grab the numbers
-				for _, v = range t.Line[pos:] {
-					if v == ' ' || v == '\n' {
-						break
-					}
-					t.Val = append(t.Val, v)
-				}
-				// any conversion error results in 0
-				n, err = helpers.ParseUint(t.Val[nameLen:])
-				if err != nil {
-					t.Errs <- &joe.ParseError{Info: string(t.Val[:nameLen]), Err: err}
-				}
-
-				v = t.Val[0]
-				// evaluate the key
-				if v == 'A' {
-					if t.Val[5] == 'e' && nameLen == 6 {
-						inf.Active = n
-					}
-					continue
-				}
-				if v == 'I' {
-					if nameLen == 8 {
-						inf.Inactive = n
-					}
-					continue
-				}
-				if v == 'M' {
-					v = t.Val[3]
-					if nameLen < 8 {
-						if v == 'p' {
-							inf.Mapped = n
-							continue
-						}
-						if v == 'F' {
-							inf.MemFree = n
-						}
-						continue
-					}
-					if v == 'A' {
-						inf.MemAvailable = n
-						continue
-					}
-					inf.MemTotal = n
-					continue
-				}
-				if v == 'S' {
-					v = t.Val[1]
-					if v == 'w' {
-						if t.Val[4] == 'C' {
-							inf.SwapCached = n
-							continue
-						}
-						if t.Val[4] == 'F' {
-							inf.SwapFree = n
-							continue
-						}
-						inf.SwapTotal = n
-						continue
-					}
-				}
-			}
-			t.Data <- inf
+			t.Data <- *inf
 		}
 	}
 }
@@ -0,0 +1,108 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meminfo
+
+// Derived holds the memory metrics that are derived from /proc/meminfo using
+// the same formulas as procps-ng's free. All sizes are in bytes; the
+// /proc/meminfo values are in kB (KiB).
+//
+// Used is MemTotal - MemAvailable. Cache is Cached + SReclaimable and
+// BuffCache is Buffers + Cache. If MemAvailable is greater than MemTotal, as
+// can happen in containers, MemFree is used as the available memory. Kernels
+// older than 3.14 don't have MemAvailable; for those, MemFree is used as the
+// available memory and Used is MemTotal - MemFree - Buffers - Cache, which is
+// what older versions of free did. If a calculation would be negative,
+// MemTotal - MemFree is used for Used.
+//
+// AvailablePercent and UsedPercent are percentages of MemTotal.
+// SwapUsedPercent is a percentage of SwapTotal; it is 0 if there isn't any
+// swap. CommitRatio is Committed_AS / CommitLimit; a ratio greater than 1
+// means that more memory has been committed than the commit limit, which
+// only matters when strict overcommit accounting is in use.
+type Derived struct {
+	Total            uint64  `json:"total"`
+	Used             uint64  `json:"used"`
+	Free             uint64  `json:"free"`
+	Shared           uint64  `json:"shared"`
+	Buffers          uint64  `json:"buffers"`
+	Cache            uint64  `json:"cache"`
+	BuffCache        uint64  `json:"buff_cache"`
+	Available        uint64  `json:"available"`
+	UsedPercent      float32 `json:"used_percent"`
+	AvailablePercent float32 `json:"available_percent"`
+	SwapTotal        uint64  `json:"swap_total"`
+	SwapUsed         uint64  `json:"swap_used"`
+	SwapFree         uint64  `json:"swap_free"`
+	SwapUsedPercent  float32 `json:"swap_used_percent"`
+	CommitLimit      uint64  `json:"commit_limit"`
+	CommittedAS      uint64  `json:"committed_as"`
+	CommitRatio      float32 `json:"commit_ratio"`
+}
+
+// Derive returns the derived memory metrics for the Info. Only the following
+// fields are used, so the metrics can be calculated for a partially populated
+// Info, e.g. one created from a membasic.Info: MemTotal, MemFree,
+// MemAvailable, Buffers, Cached, SReclaimable, Shmem, SwapTotal, SwapFree,
+// CommitLimit, and CommittedAS.
+func (inf *Info) Derive() Derived {
+	cache := inf.Cached + inf.SReclaimable
+	d := Derived{
+		Total:       inf.MemTotal * 1024,
+		Free:        inf.MemFree * 1024,
+		Shared:      inf.Shmem * 1024,
+		Buffers:     inf.Buffers * 1024,
+		Cache:       cache * 1024,
+		BuffCache:   (inf.Buffers + cache) * 1024,
+		SwapTotal:   inf.SwapTotal * 1024,
+		SwapFree:    inf.SwapFree * 1024,
+		CommitLimit: inf.CommitLimit * 1024,
+		CommittedAS: inf.CommittedAS * 1024,
+	}
+	avail := inf.MemAvailable
+	if avail > inf.MemTotal {
+		avail = inf.MemFree
+	}
+	// used is total - n; if that would be negative, total - free is used.
+	used := func(n uint64) uint64 {
+		if n <= inf.MemTotal {
+			return inf.MemTotal - n
+		}
+		if inf.MemFree <= inf.MemTotal {
+			return inf.MemTotal - inf.MemFree
+		}
+		return 0
+	}
+	var u uint64
+	if inf.MemAvailable == 0 {
+		// MemAvailable isn't available on kernels older than 3.14.
+		avail = inf.MemFree
+		u = used(inf.MemFree + inf.Buffers + cache)
+	} else {
+		u = used(avail)
+	}
+	d.Used = u * 1024
+	d.Available = avail * 1024
+	if inf.MemTotal > 0 {
+		d.UsedPercent = float32(u) / float32(inf.MemTotal) * 100
+		d.AvailablePercent = float32(avail) / float32(inf.MemTotal) * 100
+	}
+	if inf.SwapTotal > inf.SwapFree {
+		d.SwapUsed = (inf.SwapTotal - inf.SwapFree) * 1024
+		d.SwapUsedPercent = float32(inf.SwapTotal-inf.SwapFree) / float32(inf.SwapTotal) * 100
+	}
+	if inf.CommitLimit > 0 {
+		d.CommitRatio = float32(inf.CommittedAS) / float32(inf.CommitLimit)
+	}
+	return d
+}
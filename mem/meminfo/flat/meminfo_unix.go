@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package meminfo processes a subset of the /proc/meminfo file. Instead of
+// Package meminfo processes the memory information, /proc/meminfo. Instead of
 // returning a Go struct, it returns Flatbuffer serialized bytes. A function to
 // deserialize the Flatbuffer serialized bytes into a meminfo.Info struct is
 // provided.
@@ -21,11 +21,10 @@
 package meminfo
 
 import (
-	"io"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/c3sr/joefriday/helpers"
 	fb "github.com/google/flatbuffers/go"
 	joe "github.com/c3sr/joefriday"
 	mem "github.com/c3sr/joefriday/mem/meminfo"
@@ -78,6 +77,7 @@ func Get() (p []byte, err error) {
 func (prof *Profiler) Serialize(inf *mem.Info) []byte {
 	// ensure the Builder is in a usable state.
 	prof.Builder.Reset()
+	extra := prof.SerializeExtra(inf.Extra)
 	structs.InfoStart(prof.Builder)
 	structs.InfoAddTimestamp(prof.Builder, inf.Timestamp)
 	structs.InfoAddActive(prof.Builder, inf.Active)
@@ -123,6 +123,27 @@ func (prof *Profiler) Serialize(inf *mem.Info) []byte {
 	structs.InfoAddVmallocUsed(prof.Builder, inf.VmallocUsed)
 	structs.InfoAddWriteback(prof.Builder, inf.Writeback)
 	structs.InfoAddWritebackTmp(prof.Builder, inf.WritebackTmp)
+	structs.InfoAddCmaFree(prof.Builder, inf.CmaFree)
+	structs.InfoAddCmaTotal(prof.Builder, inf.CmaTotal)
+	structs.InfoAddDirectMap1G(prof.Builder, inf.DirectMap1G)
+	structs.InfoAddFileHugePages(prof.Builder, inf.FileHugePages)
+	structs.InfoAddFilePmdMapped(prof.Builder, inf.FilePmdMapped)
+	structs.InfoAddHighFree(prof.Builder, inf.HighFree)
+	structs.InfoAddHighTotal(prof.Builder, inf.HighTotal)
+	structs.InfoAddHugetlb(prof.Builder, inf.Hugetlb)
+	structs.InfoAddKReclaimable(prof.Builder, inf.KReclaimable)
+	structs.InfoAddLowFree(prof.Builder, inf.LowFree)
+	structs.InfoAddLowTotal(prof.Builder, inf.LowTotal)
+	structs.InfoAddPercpu(prof.Builder, inf.Percpu)
+	structs.InfoAddSecPageTables(prof.Builder, inf.SecPageTables)
+	structs.InfoAddShadowCallStack(prof.Builder, inf.ShadowCallStack)
+	structs.InfoAddShmemHugePages(prof.Builder, inf.ShmemHugePages)
+	structs.InfoAddShmemPmdMapped(prof.Builder, inf.ShmemPmdMapped)
+	structs.InfoAddUnaccepted(prof.Builder, inf.Unaccepted)
+	structs.InfoAddZswap(prof.Builder, inf.Zswap)
+	structs.InfoAddZswapped(prof.Builder, inf.Zswapped)
+	structs.InfoAddBalloon(prof.Builder, inf.Balloon)
+	structs.InfoAddExtra(prof.Builder, extra)
 	prof.Builder.Finish(structs.InfoEnd(prof.Builder))
 	p := prof.Builder.Bytes[prof.Builder.Head():]
 	// copy them (otherwise gets lost in reset)
@@ -131,6 +152,29 @@ func (prof *Profiler) Serialize(inf *mem.Info) []byte {
 	return tmp
 }
 
+// SerializeExtra serializes the keys that don't have an Info field as a
+// KeyValue vector, sorted by key, and returns the resulting UOffsetT.
+func (prof *Profiler) SerializeExtra(m map[string]uint64) fb.UOffsetT {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]fb.UOffsetT, len(keys))
+	for i, k := range keys {
+		key := prof.Builder.CreateString(k)
+		structs.KeyValueStart(prof.Builder)
+		structs.KeyValueAddKey(prof.Builder, key)
+		structs.KeyValueAddValue(prof.Builder, m[k])
+		kvs[i] = structs.KeyValueEnd(prof.Builder)
+	}
+	structs.InfoStartExtraVector(prof.Builder, len(kvs))
+	for i := len(kvs) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(kvs[i])
+	}
+	return prof.Builder.EndVector(len(kvs))
+}
+
 // Serialize the memory information using Flatbuffers with the package's global
 // Profiler.
 func Serialize(inf *mem.Info) (p []byte, err error) {
@@ -194,6 +238,37 @@ func Deserialize(p []byte) *mem.Info {
 	info.VmallocUsed = infoFlat.VmallocUsed()
 	info.Writeback = infoFlat.Writeback()
 	info.WritebackTmp = infoFlat.WritebackTmp()
+	info.CmaFree = infoFlat.CmaFree()
+	info.CmaTotal = infoFlat.CmaTotal()
+	info.DirectMap1G = infoFlat.DirectMap1G()
+	info.FileHugePages = infoFlat.FileHugePages()
+	info.FilePmdMapped = infoFlat.FilePmdMapped()
+	info.HighFree = infoFlat.HighFree()
+	info.HighTotal = infoFlat.HighTotal()
+	info.Hugetlb = infoFlat.Hugetlb()
+	info.KReclaimable = infoFlat.KReclaimable()
+	info.LowFree = infoFlat.LowFree()
+	info.LowTotal = infoFlat.LowTotal()
+	info.Percpu = infoFlat.Percpu()
+	info.SecPageTables = infoFlat.SecPageTables()
+	info.ShadowCallStack = infoFlat.ShadowCallStack()
+	info.ShmemHugePages = infoFlat.ShmemHugePages()
+	info.ShmemPmdMapped = infoFlat.ShmemPmdMapped()
+	info.Unaccepted = infoFlat.Unaccepted()
+	info.Zswap = infoFlat.Zswap()
+	info.Zswapped = infoFlat.Zswapped()
+	info.Balloon = infoFlat.Balloon()
+	if infoFlat.ExtraLength() > 0 {
+		kv := &structs.KeyValue{}
+		info.Extra = make(map[string]uint64, infoFlat.ExtraLength())
+		for i := 0; i < infoFlat.ExtraLength(); i++ {
+			if infoFlat.Extra(kv, i) {
+				info.Extra[string(kv.Key())] = kv.Value()
+			}
+		}
+	}
+	// the derived metrics aren't serialized.
+	info.Derived = info.Derive()
 	return info
 }
 
@@ -221,241 +296,17 @@ func NewTicker(d time.Duration) (joe.Tocker, error) {
 
 // Run runs the ticker.
 func (t *Ticker) Run() {
-	// predeclare some vars
-	var (
-		i, pos, nameLen int
-		v               byte
-		n               uint64
-		err             error
-	)
-	// ticker
-Tick:
 	for {
 		select {
 		case <-t.Done:
 			return
 		case <-t.C:
-			t.Builder.Reset()
-			err = t.Profiler.Profiler.Reset()
+			p, err := t.Get()
 			if err != nil {
 				t.Errs <- err
 				continue
 			}
-			structs.InfoStart(t.Builder)
-			structs.InfoAddTimestamp(t.Builder, time.Now().UTC().UnixNano())
-			for {
-				t.Val = t.Val[:0]
-				t.Line, err = t.ReadSlice('\n')
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					// An error results in sending error message and stop processing of this tick.
-					t.Errs <- &joe.ReadError{Err: err}
-					continue Tick
-				}
-				// first grab the key name (everything up to the ':')
-				for i, v = range t.Line {
-					if v == 0x3A {
-						t.Val = t.Line[:i]
-						pos = i + 1 // skip the :
-						break
-					}
-				}
-				nameLen = len(t.Val)
-				// skip all spaces
-				for i, v = range t.Line[pos:] {
-					if v != 0x20 {
-						pos += i
-						break
-					}
-				}
-
-				// grab the numbers
-				for _, v = range t.Line[pos:] {
-					if v == 0x20 || v == '\n' {
-						break
-					}
-					t.Val = append(t.Val, v)
-				}
-				// any conversion error results in 0
-				n, err = helpers.ParseUint(t.Val[nameLen:])
-				if err != nil {
-					t.Errs <- &joe.ParseError{Info: string(t.Val[:nameLen]), Err: err}
-					continue
-				}
-				v = t.Val[0]
-				if v == 'A' {
-					if t.Val[5] == 'e' {
-						if nameLen == 6 {
-							structs.InfoAddActive(t.Builder, n)
-							continue
-						}
-						if t.Val[7] == 'a' {
-							structs.InfoAddActiveAnon(t.Builder, n)
-							continue
-						}
-						structs.InfoAddActiveFile(t.Builder, n)
-						continue
-					}
-					if nameLen == 9 {
-						structs.InfoAddAnonPages(t.Builder, n)
-						continue
-					}
-					structs.InfoAddAnonHugePages(t.Builder, n)
-					continue
-				}
-				if v == 'C' {
-					if nameLen == 6 {
-						structs.InfoAddCached(t.Builder, n)
-						continue
-					}
-					if nameLen == 11 {
-						structs.InfoAddCommitLimit(t.Builder, n)
-						continue
-					}
-					structs.InfoAddCommittedAS(t.Builder, n)
-					continue
-				}
-				if v == 'D' {
-					if nameLen == 5 {
-						structs.InfoAddDirty(t.Builder, n)
-						continue
-					}
-					if t.Val[10] == 'k' {
-						structs.InfoAddDirectMap4K(t.Builder, n)
-						continue
-					}
-					structs.InfoAddDirectMap2M(t.Builder, n)
-					continue
-				}
-				if v == 'H' {
-					if nameLen == 14 {
-						if t.Val[10] == 'F' {
-							structs.InfoAddHugePagesFree(t.Builder, n)
-							continue
-						}
-						if t.Val[10] == 'R' {
-							structs.InfoAddHugePagesRsvd(t.Builder, n)
-							continue
-						}
-						structs.InfoAddHugePagesSurp(t.Builder, n)
-					}
-					if t.Val[1] == 'a' {
-						structs.InfoAddHardwareCorrupted(t.Builder, n)
-						continue
-					}
-					if t.Val[9] == 'i' {
-						structs.InfoAddHugePagesSize(t.Builder, n)
-						continue
-					}
-					structs.InfoAddHugePagesTotal(t.Builder, n)
-					continue
-				}
-				if v == 'I' {
-					if nameLen == 8 {
-						structs.InfoAddInactive(t.Builder, n)
-						continue
-					}
-					if t.Val[9] == 'a' {
-						structs.InfoAddInactiveAnon(t.Builder, n)
-						continue
-					}
-					structs.InfoAddInactiveFile(t.Builder, n)
-				}
-				if v == 'M' {
-					v = t.Val[3]
-					if nameLen < 8 {
-						if v == 'p' {
-							structs.InfoAddMapped(t.Builder, n)
-							continue
-						}
-						if v == 'F' {
-							structs.InfoAddMemFree(t.Builder, n)
-							continue
-						}
-						structs.InfoAddMlocked(t.Builder, n)
-						continue
-					}
-					if v == 'A' {
-						structs.InfoAddMemAvailable(t.Builder, n)
-						continue
-					}
-					structs.InfoAddMemTotal(t.Builder, n)
-					continue
-				}
-				if v == 'S' {
-					v = t.Val[1]
-					if v == 'w' {
-						if t.Val[4] == 'C' {
-							structs.InfoAddSwapCached(t.Builder, n)
-							continue
-						}
-						if t.Val[4] == 'F' {
-							structs.InfoAddSwapFree(t.Builder, n)
-							continue
-						}
-						structs.InfoAddSwapTotal(t.Builder, n)
-						continue
-					}
-					if v == 'h' {
-						structs.InfoAddShmem(t.Builder, n)
-						continue
-					}
-					if v == 'l' {
-						structs.InfoAddSlab(t.Builder, n)
-						continue
-					}
-					if v == 'R' {
-						structs.InfoAddSReclaimable(t.Builder, n)
-						continue
-					}
-					structs.InfoAddSUnreclaim(t.Builder, n)
-					continue
-				}
-				if v == 'V' {
-					if t.Val[8] == 'C' {
-						structs.InfoAddVmallocChunk(t.Builder, n)
-						continue
-					}
-					if t.Val[8] == 'T' {
-						structs.InfoAddVmallocTotal(t.Builder, n)
-						continue
-					}
-					structs.InfoAddVmallocUsed(t.Builder, n)
-					continue
-				}
-				if v == 'W' {
-					if nameLen == 9 {
-						structs.InfoAddWriteback(t.Builder, n)
-						continue
-					}
-					structs.InfoAddWritebackTmp(t.Builder, n)
-					continue
-				}
-				if v == 'B' {
-					if nameLen == 6 {
-						structs.InfoAddBounce(t.Builder, n)
-						continue
-					}
-					structs.InfoAddBuffers(t.Builder, n)
-					continue
-				}
-				if v == 'K' {
-					structs.InfoAddKernelStack(t.Builder, n)
-					continue
-				}
-				if v == 'N' {
-					structs.InfoAddNFSUnstable(t.Builder, n)
-					continue
-				}
-				if v == 'P' {
-					structs.InfoAddPageTables(t.Builder, n)
-				}
-				structs.InfoAddUnevictable(t.Builder, n)
-			}
-			t.Builder.Finish(structs.InfoEnd(t.Builder))
-			t.Data <- t.Profiler.Builder.Bytes[t.Builder.Head():]
+			t.Data <- p
 		}
 	}
 }
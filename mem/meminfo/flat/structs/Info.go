@@ -373,7 +373,190 @@ func (rcv *Info) WritebackTmp() uint64 {
 	return 0
 }
 
-func InfoStart(builder *flatbuffers.Builder) { builder.StartObject(44) }
+func (rcv *Info) CmaFree() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(92))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) CmaTotal() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(94))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) DirectMap1G() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(96))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) FileHugePages() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(98))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) FilePmdMapped() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(100))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) HighFree() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(102))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) HighTotal() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(104))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Hugetlb() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(106))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) KReclaimable() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(108))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) LowFree() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(110))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) LowTotal() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(112))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Percpu() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(114))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) SecPageTables() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(116))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) ShadowCallStack() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(118))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) ShmemHugePages() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(120))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) ShmemPmdMapped() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(122))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Unaccepted() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(124))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Zswap() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(126))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Zswapped() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(128))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Balloon() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(130))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *Info) Extra(obj *KeyValue, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(132))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+	if obj == nil {
+		obj = new(KeyValue)
+	}
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *Info) ExtraLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(132))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func InfoStart(builder *flatbuffers.Builder) { builder.StartObject(65) }
 func InfoAddTimestamp(builder *flatbuffers.Builder, Timestamp int64) { builder.PrependInt64Slot(0, Timestamp, 0) }
 func InfoAddActive(builder *flatbuffers.Builder, Active uint64) { builder.PrependUint64Slot(1, Active, 0) }
 func InfoAddActiveAnon(builder *flatbuffers.Builder, ActiveAnon uint64) { builder.PrependUint64Slot(2, ActiveAnon, 0) }
@@ -418,4 +601,27 @@ func InfoAddVmallocTotal(builder *flatbuffers.Builder, VmallocTotal uint64) { bu
 func InfoAddVmallocUsed(builder *flatbuffers.Builder, VmallocUsed uint64) { builder.PrependUint64Slot(41, VmallocUsed, 0) }
 func InfoAddWriteback(builder *flatbuffers.Builder, Writeback uint64) { builder.PrependUint64Slot(42, Writeback, 0) }
 func InfoAddWritebackTmp(builder *flatbuffers.Builder, WritebackTmp uint64) { builder.PrependUint64Slot(43, WritebackTmp, 0) }
+func InfoAddCmaFree(builder *flatbuffers.Builder, CmaFree uint64) { builder.PrependUint64Slot(44, CmaFree, 0) }
+func InfoAddCmaTotal(builder *flatbuffers.Builder, CmaTotal uint64) { builder.PrependUint64Slot(45, CmaTotal, 0) }
+func InfoAddDirectMap1G(builder *flatbuffers.Builder, DirectMap1G uint64) { builder.PrependUint64Slot(46, DirectMap1G, 0) }
+func InfoAddFileHugePages(builder *flatbuffers.Builder, FileHugePages uint64) { builder.PrependUint64Slot(47, FileHugePages, 0) }
+func InfoAddFilePmdMapped(builder *flatbuffers.Builder, FilePmdMapped uint64) { builder.PrependUint64Slot(48, FilePmdMapped, 0) }
+func InfoAddHighFree(builder *flatbuffers.Builder, HighFree uint64) { builder.PrependUint64Slot(49, HighFree, 0) }
+func InfoAddHighTotal(builder *flatbuffers.Builder, HighTotal uint64) { builder.PrependUint64Slot(50, HighTotal, 0) }
+func InfoAddHugetlb(builder *flatbuffers.Builder, Hugetlb uint64) { builder.PrependUint64Slot(51, Hugetlb, 0) }
+func InfoAddKReclaimable(builder *flatbuffers.Builder, KReclaimable uint64) { builder.PrependUint64Slot(52, KReclaimable, 0) }
+func InfoAddLowFree(builder *flatbuffers.Builder, LowFree uint64) { builder.PrependUint64Slot(53, LowFree, 0) }
+func InfoAddLowTotal(builder *flatbuffers.Builder, LowTotal uint64) { builder.PrependUint64Slot(54, LowTotal, 0) }
+func InfoAddPercpu(builder *flatbuffers.Builder, Percpu uint64) { builder.PrependUint64Slot(55, Percpu, 0) }
+func InfoAddSecPageTables(builder *flatbuffers.Builder, SecPageTables uint64) { builder.PrependUint64Slot(56, SecPageTables, 0) }
+func InfoAddShadowCallStack(builder *flatbuffers.Builder, ShadowCallStack uint64) { builder.PrependUint64Slot(57, ShadowCallStack, 0) }
+func InfoAddShmemHugePages(builder *flatbuffers.Builder, ShmemHugePages uint64) { builder.PrependUint64Slot(58, ShmemHugePages, 0) }
+func InfoAddShmemPmdMapped(builder *flatbuffers.Builder, ShmemPmdMapped uint64) { builder.PrependUint64Slot(59, ShmemPmdMapped, 0) }
+func InfoAddUnaccepted(builder *flatbuffers.Builder, Unaccepted uint64) { builder.PrependUint64Slot(60, Unaccepted, 0) }
+func InfoAddZswap(builder *flatbuffers.Builder, Zswap uint64) { builder.PrependUint64Slot(61, Zswap, 0) }
+func InfoAddZswapped(builder *flatbuffers.Builder, Zswapped uint64) { builder.PrependUint64Slot(62, Zswapped, 0) }
+func InfoAddBalloon(builder *flatbuffers.Builder, Balloon uint64) { builder.PrependUint64Slot(63, Balloon, 0) }
+func InfoAddExtra(builder *flatbuffers.Builder, Extra flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(64, flatbuffers.UOffsetT(Extra), 0) }
+func InfoStartExtraVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT { return builder.StartVector(4, numElems, 4)
+}
 func InfoEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT { return builder.EndObject() }
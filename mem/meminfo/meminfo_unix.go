@@ -25,7 +25,12 @@ import (
 
 const procFile = "/proc/meminfo"
 
-// Info holds the memory information.
+// Info holds the memory information. Fields for keys that aren't available on
+// the system's kernel, e.g. Zswap on older kernels or HighTotal on 64 bit
+// systems, will be 0. Any key that doesn't have a field is in Extra, so keys
+// added by newer kernels are not lost; Extra is nil if there aren't any.
+// Derived holds the memory metrics, in bytes, that are derived from the
+// other fields; see Derived.
 type Info struct {
 	Timestamp         int64  `json:"timestamp"`
 	Active            uint64 `json:"active"`
@@ -33,24 +38,36 @@ type Info struct {
 	ActiveFile        uint64 `json:"active_file"`
 	AnonHugePages     uint64 `json:"anon_huge_pages"`
 	AnonPages         uint64 `json:"anon_pages"`
+	Balloon           uint64 `json:"balloon"`
 	Bounce            uint64 `json:"bounce"`
 	Buffers           uint64 `json:"buffers"`
 	Cached            uint64 `json:"cached"`
+	CmaFree           uint64 `json:"cma_free"`
+	CmaTotal          uint64 `json:"cma_total"`
 	CommitLimit       uint64 `json:"commit_limit"`
 	CommittedAS       uint64 `json:"commited_as"`
 	DirectMap4K       uint64 `json:"direct_map_4k"`
 	DirectMap2M       uint64 `json:"direct_map_2m"`
+	DirectMap1G       uint64 `json:"direct_map_1g"`
 	Dirty             uint64 `json:"dirty"`
+	FileHugePages     uint64 `json:"file_huge_pages"`
+	FilePmdMapped     uint64 `json:"file_pmd_mapped"`
 	HardwareCorrupted uint64 `json:"hardware_corrupted"`
+	HighFree          uint64 `json:"high_free"`
+	HighTotal         uint64 `json:"high_total"`
 	HugePagesFree     uint64 `json:"huge_pages_free"`
 	HugePagesRsvd     uint64 `json:"huge_pages_rsvd"`
 	HugePagesSize     uint64 `json:"huge_pages_size"`
 	HugePagesSurp     uint64 `json:"huge_pages_surp"`
 	HugePagesTotal    uint64 `json:"huge_pages_total"`
+	Hugetlb           uint64 `json:"hugetlb"`
 	Inactive          uint64 `json:"inactive"`
 	InactiveAnon      uint64 `json:"inactive_anon"`
 	InactiveFile      uint64 `json:"inactive_file"`
 	KernelStack       uint64 `json:"kernel_stack"`
+	KReclaimable      uint64 `json:"k_reclaimable"`
+	LowFree           uint64 `json:"low_free"`
+	LowTotal          uint64 `json:"low_total"`
 	Mapped            uint64 `json:"mapped"`
 	MemAvailable      uint64 `json:"mem_available"`
 	MemFree           uint64 `json:"mem_free"`
@@ -58,19 +75,30 @@ type Info struct {
 	Mlocked           uint64 `json:"mlocked"`
 	NFSUnstable       uint64 `json:"nfs_unstable"`
 	PageTables        uint64 `json:"page_tables"`
+	Percpu            uint64 `json:"percpu"`
+	SecPageTables     uint64 `json:"sec_page_tables"`
+	ShadowCallStack   uint64 `json:"shadow_call_stack"`
 	Shmem             uint64 `json:"shmem"`
+	ShmemHugePages    uint64 `json:"shmem_huge_pages"`
+	ShmemPmdMapped    uint64 `json:"shmem_pmd_mapped"`
 	Slab              uint64 `json:"slab"`
 	SReclaimable      uint64 `json:"s_reclaimable"`
 	SUnreclaim        uint64 `json:"s_unreclaim"`
 	SwapCached        uint64 `json:"swap_cached"`
 	SwapFree          uint64 `json:"swap_free"`
 	SwapTotal         uint64 `json:"swap_total"`
+	Unaccepted        uint64 `json:"unaccepted"`
 	Unevictable       uint64 `json:"unevictable"`
 	VmallocChunk      uint64 `json:"vmalloc_chunk"`
 	VmallocTotal      uint64 `json:"vmalloc_total"`
 	VmallocUsed       uint64 `json:"vmalloc_used"`
 	Writeback         uint64 `json:"writeback"`
 	WritebackTmp      uint64 `json:"writeback_tmp"`
+	Zswap             uint64 `json:"zswap"`
+	Zswapped          uint64 `json:"zswapped"`
+
+	Extra   map[string]uint64 `json:"extra,omitempty"`
+	Derived Derived           `json:"derived"`
 }
 
 // Profiler is used to get the memory information by processing the
@@ -147,181 +175,150 @@ func (prof *Profiler) Get() (inf *Info, err error) {
 		if err != nil {
 			return inf, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
 		}
-
-		v = prof.Val[0]
 		// evaluate the key
-		if v == 'A' {
-			if prof.Val[5] == 'e' {
-				if nameLen == 6 {
-					inf.Active = n
-					continue
-				}
-				if prof.Val[7] == 'a' {
-					inf.ActiveAnon = n
-					continue
-				}
-				inf.ActiveFile = n
-				continue
-			}
-			if nameLen == 9 {
-				inf.AnonPages = n
-				continue
-			}
-			inf.AnonHugePages = n
-			continue
-		}
-		if v == 'C' {
-			if nameLen == 6 {
-				inf.Cached = n
-				continue
-			}
-			if nameLen == 11 {
-				inf.CommitLimit = n
-				continue
-			}
-			inf.CommittedAS = n
-			continue
-		}
-		if v == 'D' {
-			if nameLen == 5 {
-				inf.Dirty = n
-				continue
-			}
-			if prof.Val[10] == 'k' {
-				inf.DirectMap4K = n
-				continue
-			}
-			inf.DirectMap2M = n
-			continue
-		}
-		if v == 'H' {
-			if nameLen == 14 {
-				if prof.Val[10] == 'F' {
-					inf.HugePagesFree = n
-					continue
-				}
-				if prof.Val[10] == 'R' {
-					inf.HugePagesRsvd = n
-					continue
-				}
-				inf.HugePagesSurp = n
-			}
-			if prof.Val[1] == 'a' {
-				inf.HardwareCorrupted = n
-				continue
-			}
-			if prof.Val[9] == 'i' {
-				inf.HugePagesSize = n
-				continue
-			}
-			inf.HugePagesTotal = n
-			continue
-		}
-		if v == 'I' {
-			if nameLen == 8 {
-				inf.Inactive = n
-				continue
-			}
-			if prof.Val[9] == 'a' {
-				inf.InactiveAnon = n
-				continue
-			}
-			inf.InactiveFile = n
-		}
-		if v == 'M' {
-			v = prof.Val[3]
-			if nameLen < 8 {
-				if v == 'p' {
-					inf.Mapped = n
-					continue
-				}
-				if v == 'F' {
-					inf.MemFree = n
-					continue
-				}
-				inf.Mlocked = n
-				continue
-			}
-			if v == 'A' {
-				inf.MemAvailable = n
-				continue
-			}
-			inf.MemTotal = n
-			continue
-		}
-		if v == 'S' {
-			v = prof.Val[1]
-			if v == 'w' {
-				if prof.Val[4] == 'C' {
-					inf.SwapCached = n
-					continue
-				}
-				if prof.Val[4] == 'F' {
-					inf.SwapFree = n
-					continue
-				}
-				inf.SwapTotal = n
-				continue
-			}
-			if v == 'h' {
-				inf.Shmem = n
-				continue
-			}
-			if v == 'l' {
-				inf.Slab = n
-				continue
-			}
-			if v == 'R' {
-				inf.SReclaimable = n
-				continue
-			}
-			inf.SUnreclaim = n
-			continue
-		}
-		if v == 'V' {
-			if prof.Val[8] == 'C' {
-				inf.VmallocChunk = n
-				continue
-			}
-			if prof.Val[8] == 'T' {
-				inf.VmallocTotal = n
-				continue
-			}
-			inf.VmallocUsed = n
-			continue
-		}
-		if v == 'W' {
-			if nameLen == 9 {
-				inf.Writeback = n
-				continue
-			}
-			inf.WritebackTmp = n
-			continue
-		}
-		if v == 'B' {
-			if nameLen == 6 {
-				inf.Bounce = n
-				continue
-			}
-			inf.Buffers = n
-			continue
-		}
-		if v == 'K' {
-			inf.KernelStack = n
-			continue
-		}
-		if v == 'N' {
-			inf.NFSUnstable = n
-			continue
-		}
-		if v == 'P' {
-			inf.PageTables = n
-		}
-		inf.Unevictable = n
+		inf.set(string(prof.Val[:nameLen]), n)
 	}
+	inf.Derived = inf.Derive()
 	return inf, nil
 }
 
+// set sets the field for key to n. A key without a field is added to Extra.
+func (inf *Info) set(key string, n uint64) {
+	switch key {
+	case "Active":
+		inf.Active = n
+	case "Active(anon)":
+		inf.ActiveAnon = n
+	case "Active(file)":
+		inf.ActiveFile = n
+	case "AnonHugePages":
+		inf.AnonHugePages = n
+	case "AnonPages":
+		inf.AnonPages = n
+	case "Balloon":
+		inf.Balloon = n
+	case "Bounce":
+		inf.Bounce = n
+	case "Buffers":
+		inf.Buffers = n
+	case "Cached":
+		inf.Cached = n
+	case "CmaFree":
+		inf.CmaFree = n
+	case "CmaTotal":
+		inf.CmaTotal = n
+	case "CommitLimit":
+		inf.CommitLimit = n
+	case "Committed_AS":
+		inf.CommittedAS = n
+	case "DirectMap4k":
+		inf.DirectMap4K = n
+	case "DirectMap2M":
+		inf.DirectMap2M = n
+	case "DirectMap1G":
+		inf.DirectMap1G = n
+	case "Dirty":
+		inf.Dirty = n
+	case "FileHugePages":
+		inf.FileHugePages = n
+	case "FilePmdMapped":
+		inf.FilePmdMapped = n
+	case "HardwareCorrupted":
+		inf.HardwareCorrupted = n
+	case "HighFree":
+		inf.HighFree = n
+	case "HighTotal":
+		inf.HighTotal = n
+	case "HugePages_Free":
+		inf.HugePagesFree = n
+	case "HugePages_Rsvd":
+		inf.HugePagesRsvd = n
+	case "Hugepagesize":
+		inf.HugePagesSize = n
+	case "HugePages_Surp":
+		inf.HugePagesSurp = n
+	case "HugePages_Total":
+		inf.HugePagesTotal = n
+	case "Hugetlb":
+		inf.Hugetlb = n
+	case "Inactive":
+		inf.Inactive = n
+	case "Inactive(anon)":
+		inf.InactiveAnon = n
+	case "Inactive(file)":
+		inf.InactiveFile = n
+	case "KernelStack":
+		inf.KernelStack = n
+	case "KReclaimable":
+		inf.KReclaimable = n
+	case "LowFree":
+		inf.LowFree = n
+	case "LowTotal":
+		inf.LowTotal = n
+	case "Mapped":
+		inf.Mapped = n
+	case "MemAvailable":
+		inf.MemAvailable = n
+	case "MemFree":
+		inf.MemFree = n
+	case "MemTotal":
+		inf.MemTotal = n
+	case "Mlocked":
+		inf.Mlocked = n
+	case "NFS_Unstable":
+		inf.NFSUnstable = n
+	case "PageTables":
+		inf.PageTables = n
+	case "Percpu":
+		inf.Percpu = n
+	case "SecPageTables":
+		inf.SecPageTables = n
+	case "ShadowCallStack":
+		inf.ShadowCallStack = n
+	case "Shmem":
+		inf.Shmem = n
+	case "ShmemHugePages":
+		inf.ShmemHugePages = n
+	case "ShmemPmdMapped":
+		inf.ShmemPmdMapped = n
+	case "Slab":
+		inf.Slab = n
+	case "SReclaimable":
+		inf.SReclaimable = n
+	case "SUnreclaim":
+		inf.SUnreclaim = n
+	case "SwapCached":
+		inf.SwapCached = n
+	case "SwapFree":
+		inf.SwapFree = n
+	case "SwapTotal":
+		inf.SwapTotal = n
+	case "Unaccepted":
+		inf.Unaccepted = n
+	case "Unevictable":
+		inf.Unevictable = n
+	case "VmallocChunk":
+		inf.VmallocChunk = n
+	case "VmallocTotal":
+		inf.VmallocTotal = n
+	case "VmallocUsed":
+		inf.VmallocUsed = n
+	case "Writeback":
+		inf.Writeback = n
+	case "WritebackTmp":
+		inf.WritebackTmp = n
+	case "Zswap":
+		inf.Zswap = n
+	case "Zswapped":
+		inf.Zswapped = n
+	default:
+		if inf.Extra == nil {
+			inf.Extra = make(map[string]uint64)
+		}
+		inf.Extra[key] = n
+	}
+}
+
 var std *Profiler
 var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
 
@@ -363,238 +360,17 @@ func NewTicker(d time.Duration) (joe.Tocker, error) {
 
 // Run runs the ticker.
 func (t *Ticker) Run() {
-	// predeclare some vars
-	var (
-		i, pos, nameLen int
-		v               byte
-		n               uint64
-		err             error
-		inf             Info
-	)
-	// ticker
 	for {
 		select {
 		case <-t.Done:
 			return
 		case <-t.C:
-			err = t.Profiler.Reset()
+			inf, err := t.Get()
 			if err != nil {
 				t.Errs <- err
 				continue
 			}
-			inf.Timestamp = time.Now().UTC().UnixNano()
-			for {
-				t.Val = t.Val[:0]
-				t.Line, err = t.ReadSlice('\n')
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					t.Errs <- &joe.ReadError{Err: err}
-				}
-				// first grab the key name (everything up to the ':')
-				for i, v = range t.Line {
-					if v == ':' {
-						pos = i + 1
-						break
-					}
-					t.Val = append(t.Val, v)
-				}
-				nameLen = len(t.Val)
-
-				// skip all spaces
-				for i, v = range t.Line[pos:] {
-					if v != ' ' {
-						pos += i
-						break
-					}
-				}
-
-				// grab the numbers
-				for _, v = range t.Line[pos:] {
-					if v == ' ' || v == '\n' {
-						break
-					}
-					t.Val = append(t.Val, v)
-				}
-				// any conversion error results in 0
-				n, err = helpers.ParseUint(t.Val[nameLen:])
-				if err != nil {
-					t.Errs <- &joe.ParseError{Info: string(t.Val[:nameLen]), Err: err}
-				}
-
-				v = t.Val[0]
-				// evaluate the key
-				if v == 'A' {
-					if t.Val[5] == 'e' {
-						if nameLen == 6 {
-							inf.Active = n
-							continue
-						}
-						if t.Val[7] == 'a' {
-							inf.ActiveAnon = n
-							continue
-						}
-						inf.ActiveFile = n
-						continue
-					}
-					if nameLen == 9 {
-						inf.AnonPages = n
-						continue
-					}
-					inf.AnonHugePages = n
-					continue
-				}
-				if v == 'C' {
-					if nameLen == 6 {
-						inf.Cached = n
-						continue
-					}
-					if nameLen == 11 {
-						inf.CommitLimit = n
-						continue
-					}
-					inf.CommittedAS = n
-					continue
-				}
-				if v == 'D' {
-					if nameLen == 5 {
-						inf.Dirty = n
-						continue
-					}
-					if t.Val[10] == 'k' {
-						inf.DirectMap4K = n
-						continue
-					}
-					inf.DirectMap2M = n
-					continue
-				}
-				if v == 'H' {
-					if nameLen == 14 {
-						if t.Val[10] == 'F' {
-							inf.HugePagesFree = n
-							continue
-						}
-						if t.Val[10] == 'R' {
-							inf.HugePagesRsvd = n
-							continue
-						}
-						inf.HugePagesSurp = n
-					}
-					if t.Val[1] == 'a' {
-						inf.HardwareCorrupted = n
-						continue
-					}
-					if t.Val[9] == 'i' {
-						inf.HugePagesSize = n
-						continue
-					}
-					inf.HugePagesTotal = n
-					continue
-				}
-				if v == 'I' {
-					if nameLen == 8 {
-						inf.Inactive = n
-						continue
-					}
-					if t.Val[9] == 'a' {
-						inf.InactiveAnon = n
-						continue
-					}
-					inf.InactiveFile = n
-				}
-				if v == 'M' {
-					v = t.Val[3]
-					if nameLen < 8 {
-						if v == 'p' {
-							inf.Mapped = n
-							continue
-						}
-						if v == 'F' {
-							inf.MemFree = n
-							continue
-						}
-						inf.Mlocked = n
-						continue
-					}
-					if v == 'A' {
-						inf.MemAvailable = n
-						continue
-					}
-					inf.MemTotal = n
-					continue
-				}
-				if v == 'S' {
-					v = t.Val[1]
-					if v == 'w' {
-						if t.Val[4] == 'C' {
-							inf.SwapCached = n
-							continue
-						}
-						if t.Val[4] == 'F' {
-							inf.SwapFree = n
-							continue
-						}
-						inf.SwapTotal = n
-						continue
-					}
-					if v == 'h' {
-						inf.Shmem = n
-						continue
-					}
-					if v == 'l' {
-						inf.Slab = n
-						continue
-					}
-					if v == 'R' {
-						inf.SReclaimable = n
-						continue
-					}
-					inf.SUnreclaim = n
-					continue
-				}
-				if v == 'V' {
-					if t.Val[8] == 'C' {
-						inf.VmallocChunk = n
-						continue
-					}
-					if t.Val[8] == 'T' {
-						inf.VmallocTotal = n
-						continue
-					}
-					inf.VmallocUsed = n
-					continue
-				}
-				if v == 'W' {
-					if nameLen == 9 {
-						inf.Writeback = n
-						continue
-					}
-					inf.WritebackTmp = n
-					continue
-				}
-				if v == 'B' {
-					if nameLen == 6 {
-						inf.Bounce = n
-						continue
-					}
-					inf.Buffers = n
-					continue
-				}
-				if v == 'K' {
-					inf.KernelStack = n
-					continue
-				}
-				if v == 'N' {
-					inf.NFSUnstable = n
-					continue
-				}
-				if v == 'P' {
-					inf.PageTables = n
-				}
-				inf.Unevictable = n
-			}
-			t.Data <- inf
+			t.Data <- *inf
 		}
 	}
 }
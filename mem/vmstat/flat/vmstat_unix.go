@@ -0,0 +1,253 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vmstat gets the system's virtual memory statistics, /proc/vmstat.
+// Instead of returning a Go struct, it returns Flatbuffer serialized bytes.
+// Functions to deserialize the Flatbuffer serialized bytes into vmstat.VMStat
+// and vmstat.Rates structs are provided.
+//
+// Note: the package name is vmstat and not the final element of the import
+// path (flat).
+package vmstat
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	vm "github.com/c3sr/joefriday/mem/vmstat"
+	"github.com/c3sr/joefriday/mem/vmstat/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the virtual memory statistics, /proc/vmstat,
+// using Flatbuffers.
+type Profiler struct {
+	*vm.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := vm.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the current virtual memory statistics as Flatbuffer serialized
+// bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	v, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(v), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current virtual memory statistics as Flatbuffer serialized
+// bytes using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+	return std.Get()
+}
+
+// Serialize serializes vmstat.VMStat using Flatbuffers.
+func (prof *Profiler) Serialize(v *vm.VMStat) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	statsF := make([]fb.UOffsetT, len(v.Stat))
+	for i := range v.Stat {
+		name := prof.Builder.CreateString(v.Stat[i].Name)
+		structs.StatStart(prof.Builder)
+		structs.StatAddName(prof.Builder, name)
+		structs.StatAddValue(prof.Builder, v.Stat[i].Value)
+		statsF[i] = structs.StatEnd(prof.Builder)
+	}
+	structs.VMStatStartStatVector(prof.Builder, len(statsF))
+	for i := len(statsF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(statsF[i])
+	}
+	statsV := prof.Builder.EndVector(len(statsF))
+	structs.VMStatStart(prof.Builder)
+	structs.VMStatAddTimestamp(prof.Builder, v.Timestamp)
+	structs.VMStatAddStat(prof.Builder, statsV)
+	prof.Builder.Finish(structs.VMStatEnd(prof.Builder))
+	return copyBytes(prof.Builder)
+}
+
+// Serialize serializes vmstat.VMStat using Flatbuffers with the package's
+// global Profiler.
+func Serialize(v *vm.VMStat) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(v), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// vmstat.VMStat.
+func Deserialize(p []byte) *vm.VMStat {
+	flatV := structs.GetRootAsVMStat(p, 0)
+	v := &vm.VMStat{Timestamp: flatV.Timestamp(), Stat: make([]vm.Stat, flatV.StatLength())}
+	statF := &structs.Stat{}
+	for i := range v.Stat {
+		if flatV.Stat(statF, i) {
+			v.Stat[i].Name = string(statF.Name())
+			v.Stat[i].Value = statF.Value()
+		}
+	}
+	return v
+}
+
+// SerializeRates serializes vmstat.Rates using Flatbuffers.
+func (prof *Profiler) SerializeRates(r *vm.Rates) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	ratesF := make([]fb.UOffsetT, len(r.Stat))
+	for i := range r.Stat {
+		name := prof.Builder.CreateString(r.Stat[i].Name)
+		structs.StatRateStart(prof.Builder)
+		structs.StatRateAddName(prof.Builder, name)
+		structs.StatRateAddRate(prof.Builder, r.Stat[i].Rate)
+		ratesF[i] = structs.StatRateEnd(prof.Builder)
+	}
+	structs.RatesStartStatVector(prof.Builder, len(ratesF))
+	for i := len(ratesF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(ratesF[i])
+	}
+	ratesV := prof.Builder.EndVector(len(ratesF))
+	structs.RatesStart(prof.Builder)
+	structs.RatesAddTimestamp(prof.Builder, r.Timestamp)
+	structs.RatesAddTimeDelta(prof.Builder, r.TimeDelta)
+	structs.RatesAddPgPgIn(prof.Builder, r.PgPgIn)
+	structs.RatesAddPgPgOut(prof.Builder, r.PgPgOut)
+	structs.RatesAddPSwpIn(prof.Builder, r.PSwpIn)
+	structs.RatesAddPSwpOut(prof.Builder, r.PSwpOut)
+	structs.RatesAddPgFault(prof.Builder, r.PgFault)
+	structs.RatesAddPgMajFault(prof.Builder, r.PgMajFault)
+	structs.RatesAddPgScanKswapd(prof.Builder, r.PgScanKswapd)
+	structs.RatesAddPgScanDirect(prof.Builder, r.PgScanDirect)
+	structs.RatesAddPgStealKswapd(prof.Builder, r.PgStealKswapd)
+	structs.RatesAddPgStealDirect(prof.Builder, r.PgStealDirect)
+	structs.RatesAddCompactStall(prof.Builder, r.CompactStall)
+	structs.RatesAddOOMKill(prof.Builder, r.OOMKill)
+	structs.RatesAddStat(prof.Builder, ratesV)
+	prof.Builder.Finish(structs.RatesEnd(prof.Builder))
+	return copyBytes(prof.Builder)
+}
+
+// DeserializeRates takes some Flatbuffer serialized bytes and deserializes
+// them as vmstat.Rates.
+func DeserializeRates(p []byte) *vm.Rates {
+	flatR := structs.GetRootAsRates(p, 0)
+	r := &vm.Rates{
+		Timestamp:     flatR.Timestamp(),
+		TimeDelta:     flatR.TimeDelta(),
+		PgPgIn:        flatR.PgPgIn(),
+		PgPgOut:       flatR.PgPgOut(),
+		PSwpIn:        flatR.PSwpIn(),
+		PSwpOut:       flatR.PSwpOut(),
+		PgFault:       flatR.PgFault(),
+		PgMajFault:    flatR.PgMajFault(),
+		PgScanKswapd:  flatR.PgScanKswapd(),
+		PgScanDirect:  flatR.PgScanDirect(),
+		PgStealKswapd: flatR.PgStealKswapd(),
+		PgStealDirect: flatR.PgStealDirect(),
+		CompactStall:  flatR.CompactStall(),
+		OOMKill:       flatR.OOMKill(),
+		Stat:          make([]vm.StatRate, flatR.StatLength()),
+	}
+	rateF := &structs.StatRate{}
+	for i := range r.Stat {
+		if flatR.Stat(rateF, i) {
+			r.Stat[i].Name = string(rateF.Name())
+			r.Stat[i].Rate = rateF.Rate()
+		}
+	}
+	return r
+}
+
+// copyBytes returns a copy of the builder's finished bytes (otherwise they
+// get lost in reset).
+func copyBytes(bldr *fb.Builder) []byte {
+	p := bldr.Bytes[bldr.Head():]
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// Ticker delivers the per second change of the system's virtual memory
+// statistics at intervals as Flatbuffer serialized vmstat.Rates.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+	rate *vm.RateProfiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// rates for each interval and an error channel that delivers any errors
+// encountered. Stop the ticker to signal the ticker to stop running. Stopping
+// the ticker does not close the Data channel; call Close to close both the
+// ticker and the data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	rp, err := vm.NewRateProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: &Profiler{Profiler: rp.Profiler, Builder: fb.NewBuilder(0)}, rate: rp}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.rate.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- t.SerializeRates(r)
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,182 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vmstat gets the system's virtual memory statistics, /proc/vmstat.
+// Instead of returning a Go struct, it returns JSON serialized bytes.
+// Functions to deserialize the JSON serialized bytes into vmstat.VMStat and
+// vmstat.Rates structs are provided.
+//
+// Note: the package name is vmstat and not the final element of the import
+// path (json).
+package vmstat
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	vm "github.com/c3sr/joefriday/mem/vmstat"
+)
+
+// Profiler is used to process the virtual memory statistics, /proc/vmstat,
+// using JSON.
+type Profiler struct {
+	*vm.Profiler
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := vm.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p}, nil
+}
+
+// Get returns the current virtual memory statistics as JSON serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	v, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(v)
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current virtual memory statistics as JSON serialized bytes
+// using the package's global Profiler.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Serialize vmstat.VMStat using JSON.
+func (prof *Profiler) Serialize(v *vm.VMStat) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Serialize vmstat.VMStat using JSON with the package's global Profiler.
+func Serialize(v *vm.VMStat) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(v)
+}
+
+// Marshal is an alias for Serialize.
+func (prof *Profiler) Marshal(v *vm.VMStat) ([]byte, error) {
+	return prof.Serialize(v)
+}
+
+// Marshal is an alias for Serialize using the package's global profiler.
+func Marshal(v *vm.VMStat) ([]byte, error) {
+	return Serialize(v)
+}
+
+// Deserialize takes some JSON serialized bytes and unmarshals them as
+// vmstat.VMStat.
+func Deserialize(p []byte) (*vm.VMStat, error) {
+	v := &vm.VMStat{}
+	err := json.Unmarshal(p, v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Unmarshal is an alias for Deserialize.
+func Unmarshal(p []byte) (*vm.VMStat, error) {
+	return Deserialize(p)
+}
+
+// SerializeRates serializes vmstat.Rates using JSON.
+func SerializeRates(r *vm.Rates) ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// DeserializeRates takes some JSON serialized bytes and unmarshals them as
+// vmstat.Rates.
+func DeserializeRates(p []byte) (*vm.Rates, error) {
+	r := &vm.Rates{}
+	err := json.Unmarshal(p, r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Ticker delivers the per second change of the system's virtual memory
+// statistics at intervals as JSON serialized vmstat.Rates.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*vm.RateProfiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// rates for each interval and an error channel that delivers any errors
+// encountered. Stop the ticker to signal the ticker to stop running. Stopping
+// the ticker does not close the Data channel; call Close to close both the
+// ticker and the data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := vm.NewRateProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), RateProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			p, err := SerializeRates(r)
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,377 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vmstat gets the system's virtual memory statistics, /proc/vmstat.
+// Every key in /proc/vmstat is kept, in file order, so keys that are specific
+// to a kernel version are available without this package knowing about them.
+//
+// Most of the keys are event counters that are aggregated since system boot,
+// e.g. pgpgin, pswpout, pgmajfault, pgscan_kswapd, compact_stall, and
+// oom_kill; the rest, mostly the nr_ keys, are the current value of a gauge,
+// e.g. nr_free_pages. Rates provides the per second change of each key along
+// with the rates of the commonly used paging and reclaim counters.
+package vmstat
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/helpers"
+)
+
+const procFile = "/proc/vmstat"
+
+// VMStat holds the virtual memory statistics. Stat holds every key in
+// /proc/vmstat in the order in which they appear.
+type VMStat struct {
+	Timestamp int64  `json:"timestamp"`
+	Stat      []Stat `json:"stat"`
+}
+
+// Stat is a single /proc/vmstat key and its value.
+type Stat struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+}
+
+// Value returns the value of the named key. A false will be returned if the
+// key doesn't exist; not all keys are available on all kernels.
+func (v *VMStat) Value(name string) (uint64, bool) {
+	for i := range v.Stat {
+		if v.Stat[i].Name == name {
+			return v.Stat[i].Value, true
+		}
+	}
+	return 0, false
+}
+
+// Sum returns the sum of the named key and all keys that are the name followed
+// by an _ and a suffix; e.g. pgscan_kswapd is the sum of pgscan_kswapd and, on
+// older kernels, the per zone counters: pgscan_kswapd_dma,
+// pgscan_kswapd_normal, etc. Any excluded keys are not included in the sum;
+// e.g. pgscan_direct_throttle, which isn't a zone counter, should be excluded
+// from pgscan_direct. A false will be returned if none of the keys exist.
+func (v *VMStat) Sum(name string, exclude ...string) (uint64, bool) {
+	var (
+		n     uint64
+		found bool
+	)
+	for i := range v.Stat {
+		if !matchKey(v.Stat[i].Name, name, exclude) {
+			continue
+		}
+		n += v.Stat[i].Value
+		found = true
+	}
+	return n, found
+}
+
+// matchKey returns whether or not key is name or name_suffix and isn't one of
+// the excluded keys.
+func matchKey(key, name string, exclude []string) bool {
+	if !strings.HasPrefix(key, name) {
+		return false
+	}
+	if len(key) != len(name) && key[len(name)] != '_' {
+		return false
+	}
+	for _, x := range exclude {
+		if key == x {
+			return false
+		}
+	}
+	return true
+}
+
+// Profiler is used to get the virtual memory statistics by processing the
+// /proc/vmstat file.
+type Profiler struct {
+	joe.Procer
+	*joe.Buffer
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	proc, err := joe.NewProc(procFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Procer: proc, Buffer: joe.NewBuffer()}, nil
+}
+
+// Reset resources: after reset, the profiler is ready to be used again.
+func (prof *Profiler) Reset() error {
+	prof.Buffer.Reset()
+	return prof.Procer.Reset()
+}
+
+// Get returns the current virtual memory statistics.
+func (prof *Profiler) Get() (v *VMStat, err error) {
+	var (
+		i, pos, nameLen int
+		b               byte
+		n               uint64
+	)
+	err = prof.Reset()
+	if err != nil {
+		return nil, err
+	}
+	// the number of keys varies by kernel version; most have well over 100.
+	v = &VMStat{Timestamp: time.Now().UTC().UnixNano(), Stat: make([]Stat, 0, 192)}
+	for {
+		prof.Val = prof.Val[:0]
+		prof.Line, err = prof.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return v, &joe.ReadError{Err: err}
+		}
+		// first grab the key name (everything up to the ' ')
+		pos = len(prof.Line)
+		for i, b = range prof.Line {
+			if b == ' ' {
+				pos = i + 1
+				break
+			}
+			prof.Val = append(prof.Val, b)
+		}
+		nameLen = len(prof.Val)
+		if nameLen == 0 {
+			continue
+		}
+		// grab the number
+		for _, b = range prof.Line[pos:] {
+			if b == ' ' || b == '\n' {
+				break
+			}
+			prof.Val = append(prof.Val, b)
+		}
+		n, err = helpers.ParseUint(prof.Val[nameLen:])
+		if err != nil {
+			return v, &joe.ParseError{Info: string(prof.Val[:nameLen]), Err: err}
+		}
+		v.Stat = append(v.Stat, Stat{Name: string(prof.Val[:nameLen]), Value: n})
+	}
+	return v, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current virtual memory statistics using the package's
+// global Profiler.
+func Get() (v *VMStat, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Rates holds the per second change of the virtual memory statistics between
+// two snapshots. The TimeDelta field holds the time elapsed, in nanoseconds,
+// between the two snapshots. For counters, the rate is the number of events
+// per second; for gauges, e.g. nr_free_pages, it is the change in the value
+// per second, which may be negative.
+//
+// The commonly used counters have their own fields; a counter that isn't
+// available on the system's kernel will have a rate of 0. Page ins and outs,
+// PgPgIn and PgPgOut, are in KiB per second; swap ins and outs, PSwpIn and
+// PSwpOut, are in pages per second. The reclaim scan and steal rates are in
+// pages per second; on older kernels, they are the sum of the per zone
+// counters. Stat holds the rate of every key that exists in both snapshots,
+// in the order of the current snapshot.
+type Rates struct {
+	Timestamp     int64      `json:"timestamp"`
+	TimeDelta     int64      `json:"time_delta"`
+	PgPgIn        float32    `json:"pgpgin"`
+	PgPgOut       float32    `json:"pgpgout"`
+	PSwpIn        float32    `json:"pswpin"`
+	PSwpOut       float32    `json:"pswpout"`
+	PgFault       float32    `json:"pgfault"`
+	PgMajFault    float32    `json:"pgmajfault"`
+	PgScanKswapd  float32    `json:"pgscan_kswapd"`
+	PgScanDirect  float32    `json:"pgscan_direct"`
+	PgStealKswapd float32    `json:"pgsteal_kswapd"`
+	PgStealDirect float32    `json:"pgsteal_direct"`
+	CompactStall  float32    `json:"compact_stall"`
+	OOMKill       float32    `json:"oom_kill"`
+	Stat          []StatRate `json:"stat"`
+}
+
+// StatRate is the per second change of a single /proc/vmstat key.
+type StatRate struct {
+	Name string  `json:"name"`
+	Rate float32 `json:"rate"`
+}
+
+// CalculateRates returns the per second change of each key between two
+// /proc/vmstat snapshots. Keys are matched by name; a key that doesn't exist
+// in the prior snapshot is not included.
+func CalculateRates(prior, cur *VMStat) *Rates {
+	r := &Rates{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, Stat: make([]StatRate, 0, len(cur.Stat))}
+	if r.TimeDelta <= 0 {
+		return r
+	}
+	secs := float32(r.TimeDelta) / float32(time.Second)
+	for i := range cur.Stat {
+		var p *Stat
+		// keys are usually in the same order; only search if they aren't.
+		if i < len(prior.Stat) && prior.Stat[i].Name == cur.Stat[i].Name {
+			p = &prior.Stat[i]
+		} else {
+			for j := range prior.Stat {
+				if prior.Stat[j].Name == cur.Stat[i].Name {
+					p = &prior.Stat[j]
+					break
+				}
+			}
+		}
+		if p == nil {
+			continue
+		}
+		// the difference is signed as gauges can go down.
+		r.Stat = append(r.Stat, StatRate{Name: cur.Stat[i].Name, Rate: float32(int64(cur.Stat[i].Value-p.Value)) / secs})
+	}
+	r.PgPgIn = r.rate("pgpgin")
+	r.PgPgOut = r.rate("pgpgout")
+	r.PSwpIn = r.rate("pswpin")
+	r.PSwpOut = r.rate("pswpout")
+	r.PgFault = r.rate("pgfault")
+	r.PgMajFault = r.rate("pgmajfault")
+	r.PgScanKswapd = r.sum("pgscan_kswapd")
+	r.PgScanDirect = r.sum("pgscan_direct", "pgscan_direct_throttle")
+	r.PgStealKswapd = r.sum("pgsteal_kswapd")
+	r.PgStealDirect = r.sum("pgsteal_direct")
+	r.CompactStall = r.rate("compact_stall")
+	r.OOMKill = r.rate("oom_kill")
+	return r
+}
+
+// Rate returns the rate of the named key. A false will be returned if the
+// key isn't in the rates.
+func (r *Rates) Rate(name string) (float32, bool) {
+	for i := range r.Stat {
+		if r.Stat[i].Name == name {
+			return r.Stat[i].Rate, true
+		}
+	}
+	return 0, false
+}
+
+// rate returns the rate of the named key; 0 if it doesn't exist.
+func (r *Rates) rate(name string) float32 {
+	v, _ := r.Rate(name)
+	return v
+}
+
+// sum returns the sum of the rates of the named key and its per zone keys;
+// see VMStat.Sum.
+func (r *Rates) sum(name string, exclude ...string) float32 {
+	var v float32
+	for i := range r.Stat {
+		if matchKey(r.Stat[i].Name, name, exclude) {
+			v += r.Stat[i].Rate
+		}
+	}
+	return v
+}
+
+// RateProfiler is used to calculate the per second change of the virtual
+// memory statistics; the last /proc/vmstat snapshot is kept so that the rates
+// can be calculated.
+type RateProfiler struct {
+	*Profiler
+	prior *VMStat
+}
+
+// NewRateProfiler returns an initialized RateProfiler; ready to use. The
+// initial /proc/vmstat snapshot is taken during initialization.
+func NewRateProfiler() (prof *RateProfiler, err error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	v, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &RateProfiler{Profiler: p, prior: v}, nil
+}
+
+// Get returns the per second change of the virtual memory statistics since
+// the last time the RateProfiler was used. If this is the first use, the
+// rates since the RateProfiler was created are returned.
+func (prof *RateProfiler) Get() (r *Rates, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	r = CalculateRates(prof.prior, cur)
+	prof.prior = cur
+	return r, nil
+}
+
+// Ticker delivers the per second change of the system's virtual memory
+// statistics at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *Rates
+	*RateProfiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// rates for each interval and an error channel that delivers any errors
+// encountered. Stop the ticker to signal the ticker to stop running. Stopping
+// the ticker does not close the Data channel; call Close to close both the
+// ticker and the data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewRateProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *Rates), RateProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			r, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- r
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
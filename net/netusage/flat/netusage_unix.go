@@ -0,0 +1,207 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netusage calculates network device usage. Usage is calculated by
+// taking the difference between two /proc/net/dev snapshots and is reported
+// as per second rates. Instead of returning a Go struct, it returns
+// Flatbuffer serialized bytes. A function to deserialize the Flatbuffer
+// serialized bytes into a structs.DevUsage struct is provided.
+//
+// Note: the package name is netusage and not the final element of the import
+// path (flat).
+package netusage
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	usage "github.com/c3sr/joefriday/net/netusage"
+	"github.com/c3sr/joefriday/net/structs"
+	"github.com/c3sr/joefriday/net/structs/flat"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the network device usage as Flatbuffer
+// serialized bytes.
+type Profiler struct {
+	*usage.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized Profiler that uses Flatbuffers.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := usage.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the network device usage since the last time the Profiler was
+// used as Flatbuffer serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	u, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(u), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the network device usage as Flatbuffer serialized bytes using
+// the package's global Profiler. On the first call the global Profiler is
+// created and the usage is calculated using the snapshot taken during its
+// creation.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+
+	return std.Get()
+}
+
+// Serialize serializes structs.DevUsage as Flatbuffer serialized bytes.
+func (prof *Profiler) Serialize(u *structs.DevUsage) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	devF := make([]fb.UOffsetT, len(u.Device))
+	names := make([]fb.UOffsetT, len(u.Device))
+	for i := 0; i < len(names); i++ {
+		names[i] = prof.Builder.CreateString(u.Device[i].Name)
+	}
+	for i := 0; i < len(devF); i++ {
+		flat.UsageStart(prof.Builder)
+		flat.UsageAddName(prof.Builder, names[i])
+		flat.UsageAddRBytes(prof.Builder, u.Device[i].RBytes)
+		flat.UsageAddRPackets(prof.Builder, u.Device[i].RPackets)
+		flat.UsageAddRErrs(prof.Builder, u.Device[i].RErrs)
+		flat.UsageAddRDrop(prof.Builder, u.Device[i].RDrop)
+		flat.UsageAddTBytes(prof.Builder, u.Device[i].TBytes)
+		flat.UsageAddTPackets(prof.Builder, u.Device[i].TPackets)
+		flat.UsageAddTErrs(prof.Builder, u.Device[i].TErrs)
+		flat.UsageAddTDrop(prof.Builder, u.Device[i].TDrop)
+		devF[i] = flat.UsageEnd(prof.Builder)
+	}
+	flat.DevUsageStartDeviceVector(prof.Builder, len(devF))
+	for i := len(devF) - 1; i >= 0; i-- {
+		prof.Builder.PrependUOffsetT(devF[i])
+	}
+	devV := prof.Builder.EndVector(len(devF))
+	flat.DevUsageStart(prof.Builder)
+	flat.DevUsageAddTimestamp(prof.Builder, u.Timestamp)
+	flat.DevUsageAddTimeDelta(prof.Builder, u.TimeDelta)
+	flat.DevUsageAddDevice(prof.Builder, devV)
+	prof.Builder.Finish(flat.DevUsageEnd(prof.Builder))
+	p := prof.Builder.Bytes[prof.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(p))
+	copy(tmp, p)
+	return tmp
+}
+
+// Serialize serializes structs.DevUsage as Flatbuffer serialized bytes using
+// the package's global Profiler.
+func Serialize(u *structs.DevUsage) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(u), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserialize's them
+// as a structs.DevUsage.
+func Deserialize(p []byte) *structs.DevUsage {
+	u := &structs.DevUsage{}
+	devF := &flat.Usage{}
+	usageFlat := flat.GetRootAsDevUsage(p, 0)
+	u.Timestamp = usageFlat.Timestamp()
+	u.TimeDelta = usageFlat.TimeDelta()
+	len := usageFlat.DeviceLength()
+	u.Device = make([]structs.Usage, len)
+	for i := 0; i < len; i++ {
+		var dev structs.Usage
+		if usageFlat.Device(devF, i) {
+			dev.Name = string(devF.Name())
+			dev.RBytes = devF.RBytes()
+			dev.RPackets = devF.RPackets()
+			dev.RErrs = devF.RErrs()
+			dev.RDrop = devF.RDrop()
+			dev.TBytes = devF.TBytes()
+			dev.TPackets = devF.TPackets()
+			dev.TErrs = devF.TErrs()
+			dev.TDrop = devF.TDrop()
+		}
+		u.Device[i] = dev
+	}
+	return u
+}
+
+// Ticker delivers the network device usage at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
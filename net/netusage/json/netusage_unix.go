@@ -0,0 +1,164 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netusage calculates network device usage. Usage is calculated by
+// taking the difference between two /proc/net/dev snapshots and is reported
+// as per second rates. Instead of returning a Go struct, it returns JSON
+// serialized bytes. A function to deserialize the JSON serialized bytes into
+// a structs.DevUsage struct is provided.
+//
+// Note: the package name is netusage and not the final element of the import
+// path (json).
+package netusage
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	usage "github.com/c3sr/joefriday/net/netusage"
+	"github.com/c3sr/joefriday/net/structs"
+)
+
+// Profiler is used to process the network device usage as JSON serialized
+// bytes.
+type Profiler struct {
+	*usage.Profiler
+}
+
+// Returns an initialized profiler that uses JSON.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := usage.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p}, nil
+}
+
+// Get returns the network device usage since the last time the Profiler was
+// used as JSON serialized bytes.
+func (prof *Profiler) Get() (p []byte, err error) {
+	u, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(u)
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to preven data race on checking/instantiation
+
+// Get returns the network device usage as JSON serialized bytes using the
+// package's global Profiler. On the first call the global Profiler is created
+// and the usage is calculated using the snapshot taken during its creation.
+func Get() (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Serialize structs.DevUsage as JSON.
+func (prof *Profiler) Serialize(u *structs.DevUsage) ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// Serialize structs.DevUsage as JSON using package globals.
+func Serialize(u *structs.DevUsage) (p []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(u)
+}
+
+// Marshal is an alias for Serialize.
+func (prof *Profiler) Marshal(u *structs.DevUsage) ([]byte, error) {
+	return prof.Serialize(u)
+}
+
+// Marshal is an alias for Serialize using package globals.
+func Marshal(u *structs.DevUsage) ([]byte, error) {
+	return Serialize(u)
+}
+
+// Deserialize takes some JSON serialized bytes and unmarshals them as
+// structs.DevUsage.
+func Deserialize(p []byte) (*structs.DevUsage, error) {
+	u := &structs.DevUsage{}
+	err := json.Unmarshal(p, u)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Unmarshal is an alias for Deserialize.
+func Unmarshal(p []byte) (*structs.DevUsage, error) {
+	return Deserialize(p)
+}
+
+// Ticker delivers the network device usage at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			p, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,189 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netusage calculates network device usage. Usage is calculated by
+// taking the difference between two /proc/net/dev snapshots and is reported
+// as per second rates.
+package netusage
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/net/netdev"
+	"github.com/c3sr/joefriday/net/structs"
+)
+
+// Profiler is used to process the network device usage; the last
+// /proc/net/dev snapshot is kept so that usage can be calculated.
+type Profiler struct {
+	*netdev.Profiler
+	prior *structs.DevInfo
+}
+
+// Returns an initialized Profiler; ready to use. The initial /proc/net/dev
+// snapshot is taken during initialization.
+func NewProfiler() (prof *Profiler, err error) {
+	p, err := netdev.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	inf, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: p, prior: inf}, nil
+}
+
+// Get returns the network device usage since the last time the Profiler was
+// used. If this is the first use, the usage since the Profiler was created is
+// returned.
+func (prof *Profiler) Get() (u *structs.DevUsage, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	u = Calculate(prof.prior, cur)
+	prof.prior = cur
+	return u, nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get returns the network device usage using the package's global Profiler.
+// The usage is calculated from the delta between this call and the prior
+// one; on the first call the global Profiler is created and the usage is
+// calculated using the snapshot taken during its creation.
+func Get() (u *structs.DevUsage, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Calculate returns the network device usage between two /proc/net/dev
+// snapshots. Devices are matched by name. A device that doesn't exist in the
+// prior snapshot, e.g. an interface that was created between the two
+// snapshots, is not included as there is nothing to calculate its usage from;
+// a device that no longer exists is also not included. Each counter is
+// handled on its own: if a counter is lower than its prior value, it is
+// either a 32 bit counter that wrapped, see counterDelta, or a counter that
+// was reset, in which case its current value is used as the delta.
+func Calculate(prior, cur *structs.DevInfo) *structs.DevUsage {
+	u := &structs.DevUsage{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp, Device: make([]structs.Usage, 0, len(cur.Device))}
+	if u.TimeDelta <= 0 {
+		return u
+	}
+	for i := 0; i < len(cur.Device); i++ {
+		// devices are usually in the same order; only search if they aren't.
+		if i < len(prior.Device) && prior.Device[i].Name == cur.Device[i].Name {
+			u.Device = append(u.Device, calculateUsage(&prior.Device[i], &cur.Device[i], u.TimeDelta))
+			continue
+		}
+		for j := 0; j < len(prior.Device); j++ {
+			if prior.Device[j].Name == cur.Device[i].Name {
+				u.Device = append(u.Device, calculateUsage(&prior.Device[j], &cur.Device[i], u.TimeDelta))
+				break
+			}
+		}
+	}
+	return u
+}
+
+// calculateUsage calculates the usage of a single device. The delta, d, is in
+// nanoseconds.
+func calculateUsage(prior, cur *structs.Device, d int64) structs.Usage {
+	secs := float32(d) / float32(time.Second)
+	return structs.Usage{
+		Name:     cur.Name,
+		RBytes:   float32(counterDelta(prior.RBytes, cur.RBytes)) / secs,
+		RPackets: float32(counterDelta(prior.RPackets, cur.RPackets)) / secs,
+		RErrs:    float32(counterDelta(prior.RErrs, cur.RErrs)) / secs,
+		RDrop:    float32(counterDelta(prior.RDrop, cur.RDrop)) / secs,
+		TBytes:   float32(counterDelta(prior.TBytes, cur.TBytes)) / secs,
+		TPackets: float32(counterDelta(prior.TPackets, cur.TPackets)) / secs,
+		TErrs:    float32(counterDelta(prior.TErrs, cur.TErrs)) / secs,
+		TDrop:    float32(counterDelta(prior.TDrop, cur.TDrop)) / secs,
+	}
+}
+
+// counterDelta returns the change in a counter between two snapshots. If the
+// counter went backwards and both values fit in 32 bits, the counter is
+// assumed to have wrapped, e.g. it's an unsigned long on a 32 bit kernel, as
+// long as that means it advanced by less than 2^31; otherwise the counter is
+// assumed to have been reset and the current value is the delta.
+func counterDelta(prior, cur int64) int64 {
+	if cur >= prior {
+		return cur - prior
+	}
+	if prior < 1<<32 && cur < 1<<32 {
+		if d := 1<<32 - prior + cur; d < 1<<31 {
+			return d
+		}
+	}
+	// the counter started over; everything since the reset is the delta.
+	return cur
+}
+
+// Ticker delivers the system's network device usage at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *structs.DevUsage
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *structs.DevUsage), Profiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	// ticker
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			u, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- u
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -46,7 +46,21 @@ type DevInfo struct {
 // snapshots; the TimeDelta field holds the time elapsed between the
 // two snapshots used to calculate the usage.
 type DevUsage struct {
-	Timestamp  int64 `json:"timestamp"`
-	TimeDelta  int64 `json:"time_delta"`
-	Device []Device `json:"devices"`
+	Timestamp int64   `json:"timestamp"`
+	TimeDelta int64   `json:"time_delta"`
+	Device    []Usage `json:"devices"`
+}
+
+// Usage contains the usage information for a given network device. All of
+// the values are per second.
+type Usage struct {
+	Name     string  `json:"name"`
+	RBytes   float32 `json:"receive_bytes_per_sec"`
+	RPackets float32 `json:"receive_packets_per_sec"`
+	RErrs    float32 `json:"receive_errs_per_sec"`
+	RDrop    float32 `json:"receive_drop_per_sec"`
+	TBytes   float32 `json:"transmit_bytes_per_sec"`
+	TPackets float32 `json:"transmit_packets_per_sec"`
+	TErrs    float32 `json:"transmit_errs_per_sec"`
+	TDrop    float32 `json:"transmit_drop_per_sec"`
 }
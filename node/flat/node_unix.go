@@ -23,9 +23,12 @@
 package node
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	fb "github.com/google/flatbuffers/go"
+	"github.com/c3sr/joefriday"
 	numa "github.com/c3sr/joefriday/node"
 	"github.com/c3sr/joefriday/node/flat/structs"
 )
@@ -92,13 +95,105 @@ func (p *Profiler) Serialize(nodes *numa.Nodes) []byte {
 // SerializeNode serializes a Node using flatbuffers and returns the resulting
 // UOffsetT.
 func (p *Profiler) SerializeNode(node *numa.Node) fb.UOffsetT {
-	cpuList := p.Builder.CreateString(node.CPUList)
+	cpuList := p.Builder.CreateString(node.CPUList.String())
+	memInfo := p.SerializeMemInfo(&node.MemInfo)
+	numaStat := p.SerializeNumaStat(&node.NumaStat)
+	structs.NodeStartDistanceVector(p.Builder, len(node.Distance))
+	for i := len(node.Distance) - 1; i >= 0; i-- {
+		p.Builder.PrependInt32(node.Distance[i])
+	}
+	distance := p.Builder.EndVector(len(node.Distance))
 	structs.NodeStart(p.Builder)
 	structs.NodeAddID(p.Builder, node.ID)
 	structs.NodeAddCPUList(p.Builder, cpuList)
+	structs.NodeAddMemInfo(p.Builder, memInfo)
+	structs.NodeAddNumaStat(p.Builder, numaStat)
+	structs.NodeAddDistance(p.Builder, distance)
 	return structs.NodeEnd(p.Builder)
 }
 
+// SerializeMemInfo serializes a node's MemInfo using flatbuffers and returns
+// the resulting UOffsetT. The Extra keys are serialized as a KeyValue vector,
+// sorted by key.
+func (p *Profiler) SerializeMemInfo(m *numa.MemInfo) fb.UOffsetT {
+	keys := make([]string, 0, len(m.Extra))
+	for k := range m.Extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]fb.UOffsetT, len(keys))
+	for i, k := range keys {
+		key := p.Builder.CreateString(k)
+		structs.KeyValueStart(p.Builder)
+		structs.KeyValueAddKey(p.Builder, key)
+		structs.KeyValueAddValue(p.Builder, m.Extra[k])
+		kvs[i] = structs.KeyValueEnd(p.Builder)
+	}
+	structs.MemInfoStartExtraVector(p.Builder, len(kvs))
+	for i := len(kvs) - 1; i >= 0; i-- {
+		p.Builder.PrependUOffsetT(kvs[i])
+	}
+	extra := p.Builder.EndVector(len(kvs))
+	structs.MemInfoStart(p.Builder)
+	structs.MemInfoAddActive(p.Builder, m.Active)
+	structs.MemInfoAddActiveAnon(p.Builder, m.ActiveAnon)
+	structs.MemInfoAddActiveFile(p.Builder, m.ActiveFile)
+	structs.MemInfoAddAnonHugePages(p.Builder, m.AnonHugePages)
+	structs.MemInfoAddAnonPages(p.Builder, m.AnonPages)
+	structs.MemInfoAddBounce(p.Builder, m.Bounce)
+	structs.MemInfoAddDirty(p.Builder, m.Dirty)
+	structs.MemInfoAddFileHugePages(p.Builder, m.FileHugePages)
+	structs.MemInfoAddFilePages(p.Builder, m.FilePages)
+	structs.MemInfoAddFilePmdMapped(p.Builder, m.FilePmdMapped)
+	structs.MemInfoAddHighFree(p.Builder, m.HighFree)
+	structs.MemInfoAddHighTotal(p.Builder, m.HighTotal)
+	structs.MemInfoAddHugePagesFree(p.Builder, m.HugePagesFree)
+	structs.MemInfoAddHugePagesSurp(p.Builder, m.HugePagesSurp)
+	structs.MemInfoAddHugePagesTotal(p.Builder, m.HugePagesTotal)
+	structs.MemInfoAddInactive(p.Builder, m.Inactive)
+	structs.MemInfoAddInactiveAnon(p.Builder, m.InactiveAnon)
+	structs.MemInfoAddInactiveFile(p.Builder, m.InactiveFile)
+	structs.MemInfoAddKernelStack(p.Builder, m.KernelStack)
+	structs.MemInfoAddKReclaimable(p.Builder, m.KReclaimable)
+	structs.MemInfoAddLowFree(p.Builder, m.LowFree)
+	structs.MemInfoAddLowTotal(p.Builder, m.LowTotal)
+	structs.MemInfoAddMapped(p.Builder, m.Mapped)
+	structs.MemInfoAddMemFree(p.Builder, m.MemFree)
+	structs.MemInfoAddMemTotal(p.Builder, m.MemTotal)
+	structs.MemInfoAddMemUsed(p.Builder, m.MemUsed)
+	structs.MemInfoAddMlocked(p.Builder, m.Mlocked)
+	structs.MemInfoAddNFSUnstable(p.Builder, m.NFSUnstable)
+	structs.MemInfoAddPageTables(p.Builder, m.PageTables)
+	structs.MemInfoAddSecPageTables(p.Builder, m.SecPageTables)
+	structs.MemInfoAddShadowCallStack(p.Builder, m.ShadowCallStack)
+	structs.MemInfoAddShmem(p.Builder, m.Shmem)
+	structs.MemInfoAddShmemHugePages(p.Builder, m.ShmemHugePages)
+	structs.MemInfoAddShmemPmdMapped(p.Builder, m.ShmemPmdMapped)
+	structs.MemInfoAddSlab(p.Builder, m.Slab)
+	structs.MemInfoAddSReclaimable(p.Builder, m.SReclaimable)
+	structs.MemInfoAddSUnreclaim(p.Builder, m.SUnreclaim)
+	structs.MemInfoAddSwapCached(p.Builder, m.SwapCached)
+	structs.MemInfoAddUnaccepted(p.Builder, m.Unaccepted)
+	structs.MemInfoAddUnevictable(p.Builder, m.Unevictable)
+	structs.MemInfoAddWriteback(p.Builder, m.Writeback)
+	structs.MemInfoAddWritebackTmp(p.Builder, m.WritebackTmp)
+	structs.MemInfoAddExtra(p.Builder, extra)
+	return structs.MemInfoEnd(p.Builder)
+}
+
+// SerializeNumaStat serializes a node's NumaStat using flatbuffers and
+// returns the resulting UOffsetT.
+func (p *Profiler) SerializeNumaStat(st *numa.NumaStat) fb.UOffsetT {
+	structs.NumaStatStart(p.Builder)
+	structs.NumaStatAddNumaHit(p.Builder, st.NumaHit)
+	structs.NumaStatAddNumaMiss(p.Builder, st.NumaMiss)
+	structs.NumaStatAddNumaForeign(p.Builder, st.NumaForeign)
+	structs.NumaStatAddInterleaveHit(p.Builder, st.InterleaveHit)
+	structs.NumaStatAddLocalNode(p.Builder, st.LocalNode)
+	structs.NumaStatAddOtherNode(p.Builder, st.OtherNode)
+	return structs.NumaStatEnd(p.Builder)
+}
+
 // Serialize node.Nodes using the package global profiler.
 func Serialize(nodes *numa.Nodes) (p []byte) {
 	stdMu.Lock()
@@ -116,15 +211,186 @@ func Deserialize(p []byte) *numa.Nodes {
 	l := fnodes.NodeLength()
 	nodes := &numa.Nodes{}
 	fNode := &structs.Node{}
-	node := numa.Node{}
+	fMemInfo := &structs.MemInfo{}
+	fNumaStat := &structs.NumaStat{}
 	nodes.Node = make([]numa.Node, 0, l)
 	for i := 0; i < l; i++ {
 		if !fnodes.Node(fNode, i) {
 			continue
 		}
+		node := numa.Node{}
 		node.ID = fNode.ID()
-		node.CPUList = string(fNode.CPUList())
+		// the list was created by joefriday.Set.String; a parse error can only
+		// occur if the bytes weren't serialized by this package.
+		node.CPUList, _ = joefriday.ParseSet(string(fNode.CPUList()))
+		if fNode.MemInfo(fMemInfo) != nil {
+			deserializeMemInfo(fMemInfo, &node.MemInfo)
+		}
+		if fNode.NumaStat(fNumaStat) != nil {
+			deserializeNumaStat(fNumaStat, &node.NumaStat)
+		}
+		if fNode.DistanceLength() > 0 {
+			node.Distance = make([]int32, fNode.DistanceLength())
+			for j := range node.Distance {
+				node.Distance[j] = fNode.Distance(j)
+			}
+		}
 		nodes.Node = append(nodes.Node, node)
 	}
 	return nodes
 }
+
+// deserializeMemInfo deserializes a node's MemInfo.
+func deserializeMemInfo(fm *structs.MemInfo, m *numa.MemInfo) {
+	m.Active = fm.Active()
+	m.ActiveAnon = fm.ActiveAnon()
+	m.ActiveFile = fm.ActiveFile()
+	m.AnonHugePages = fm.AnonHugePages()
+	m.AnonPages = fm.AnonPages()
+	m.Bounce = fm.Bounce()
+	m.Dirty = fm.Dirty()
+	m.FileHugePages = fm.FileHugePages()
+	m.FilePages = fm.FilePages()
+	m.FilePmdMapped = fm.FilePmdMapped()
+	m.HighFree = fm.HighFree()
+	m.HighTotal = fm.HighTotal()
+	m.HugePagesFree = fm.HugePagesFree()
+	m.HugePagesSurp = fm.HugePagesSurp()
+	m.HugePagesTotal = fm.HugePagesTotal()
+	m.Inactive = fm.Inactive()
+	m.InactiveAnon = fm.InactiveAnon()
+	m.InactiveFile = fm.InactiveFile()
+	m.KernelStack = fm.KernelStack()
+	m.KReclaimable = fm.KReclaimable()
+	m.LowFree = fm.LowFree()
+	m.LowTotal = fm.LowTotal()
+	m.Mapped = fm.Mapped()
+	m.MemFree = fm.MemFree()
+	m.MemTotal = fm.MemTotal()
+	m.MemUsed = fm.MemUsed()
+	m.Mlocked = fm.Mlocked()
+	m.NFSUnstable = fm.NFSUnstable()
+	m.PageTables = fm.PageTables()
+	m.SecPageTables = fm.SecPageTables()
+	m.ShadowCallStack = fm.ShadowCallStack()
+	m.Shmem = fm.Shmem()
+	m.ShmemHugePages = fm.ShmemHugePages()
+	m.ShmemPmdMapped = fm.ShmemPmdMapped()
+	m.Slab = fm.Slab()
+	m.SReclaimable = fm.SReclaimable()
+	m.SUnreclaim = fm.SUnreclaim()
+	m.SwapCached = fm.SwapCached()
+	m.Unaccepted = fm.Unaccepted()
+	m.Unevictable = fm.Unevictable()
+	m.Writeback = fm.Writeback()
+	m.WritebackTmp = fm.WritebackTmp()
+	if fm.ExtraLength() > 0 {
+		kv := &structs.KeyValue{}
+		m.Extra = make(map[string]uint64, fm.ExtraLength())
+		for i := 0; i < fm.ExtraLength(); i++ {
+			if fm.Extra(kv, i) {
+				m.Extra[string(kv.Key())] = kv.Value()
+			}
+		}
+	}
+}
+
+// deserializeNumaStat deserializes a node's NumaStat.
+func deserializeNumaStat(fst *structs.NumaStat, st *numa.NumaStat) {
+	st.NumaHit = fst.NumaHit()
+	st.NumaMiss = fst.NumaMiss()
+	st.NumaForeign = fst.NumaForeign()
+	st.InterleaveHit = fst.InterleaveHit()
+	st.LocalNode = fst.LocalNode()
+	st.OtherNode = fst.OtherNode()
+}
+
+// SerializeNumaStats serializes node.NumaStats using Flatbuffers.
+func (p *Profiler) SerializeNumaStats(stats *numa.NumaStats) []byte {
+	// ensure the Builder is in a usable state.
+	p.Builder.Reset()
+	uoffs := make([]fb.UOffsetT, len(stats.Node))
+	for i := range stats.Node {
+		st := p.SerializeNumaStat(&stats.Node[i].Stat)
+		structs.NodeNumaStatStart(p.Builder)
+		structs.NodeNumaStatAddID(p.Builder, stats.Node[i].ID)
+		structs.NodeNumaStatAddStat(p.Builder, st)
+		uoffs[i] = structs.NodeNumaStatEnd(p.Builder)
+	}
+	structs.NumaStatsStartNodeVector(p.Builder, len(uoffs))
+	for i := len(uoffs) - 1; i >= 0; i-- {
+		p.Builder.PrependUOffsetT(uoffs[i])
+	}
+	nodeV := p.Builder.EndVector(len(uoffs))
+	structs.NumaStatsStart(p.Builder)
+	structs.NumaStatsAddTimestamp(p.Builder, stats.Timestamp)
+	structs.NumaStatsAddNode(p.Builder, nodeV)
+	p.Builder.Finish(structs.NumaStatsEnd(p.Builder))
+	b := p.Builder.Bytes[p.Builder.Head():]
+	// copy them (otherwise gets lost in reset)
+	tmp := make([]byte, len(b))
+	copy(tmp, b)
+	return tmp
+}
+
+// DeserializeNumaStats takes some Flatbuffer serialized bytes and
+// deserializes them as node.NumaStats.
+func DeserializeNumaStats(p []byte) *numa.NumaStats {
+	fstats := structs.GetRootAsNumaStats(p, 0)
+	stats := &numa.NumaStats{Timestamp: fstats.Timestamp(), Node: make([]numa.NodeNumaStat, 0, fstats.NodeLength())}
+	fNode := &structs.NodeNumaStat{}
+	fStat := &structs.NumaStat{}
+	for i := 0; i < fstats.NodeLength(); i++ {
+		if !fstats.Node(fNode, i) {
+			continue
+		}
+		st := numa.NodeNumaStat{ID: fNode.ID()}
+		if fNode.Stat(fStat) != nil {
+			deserializeNumaStat(fStat, &st.Stat)
+		}
+		stats.Node = append(stats.Node, st)
+	}
+	return stats
+}
+
+// Ticker delivers the NUMA allocation counters of each node at intervals as
+// Flatbuffer serialized node.NumaStats.
+type Ticker struct {
+	*joefriday.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joefriday.Tocker, error) {
+	t := Ticker{Ticker: joefriday.NewTicker(d), Data: make(chan []byte), Profiler: NewProfiler()}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.NumaStats()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- t.SerializeNumaStats(s)
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -30,7 +30,55 @@ func (rcv *Node) CPUList() []byte {
 	return nil
 }
 
-func NodeStart(builder *flatbuffers.Builder) { builder.StartObject(2) }
+func (rcv *Node) MemInfo(obj *MemInfo) *MemInfo {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(MemInfo)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		return obj
+	}
+	return nil
+}
+
+func (rcv *Node) NumaStat(obj *NumaStat) *NumaStat {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(10))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(NumaStat)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		return obj
+	}
+	return nil
+}
+
+func (rcv *Node) Distance(j int) int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetInt32(a + flatbuffers.UOffsetT(j * 4))
+	}
+	return 0
+}
+
+func (rcv *Node) DistanceLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(12))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func NodeStart(builder *flatbuffers.Builder) { builder.StartObject(5) }
 func NodeAddID(builder *flatbuffers.Builder, ID int32) { builder.PrependInt32Slot(0, ID, 0) }
 func NodeAddCPUList(builder *flatbuffers.Builder, CPUList flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(CPUList), 0) }
+func NodeAddMemInfo(builder *flatbuffers.Builder, MemInfo flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(MemInfo), 0) }
+func NodeAddNumaStat(builder *flatbuffers.Builder, NumaStat flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(3, flatbuffers.UOffsetT(NumaStat), 0) }
+func NodeAddDistance(builder *flatbuffers.Builder, Distance flatbuffers.UOffsetT) { builder.PrependUOffsetTSlot(4, flatbuffers.UOffsetT(Distance), 0) }
+func NodeStartDistanceVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT { return builder.StartVector(4, numElems, 4)
+}
 func NodeEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT { return builder.EndObject() }
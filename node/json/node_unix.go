@@ -25,7 +25,9 @@ package node
 import (
 	"encoding/json"
 	"sync"
+	"time"
 
+	joe "github.com/c3sr/joefriday"
 	numa "github.com/c3sr/joefriday/node"
 )
 
@@ -103,3 +105,66 @@ func Deserialize(p []byte) (*numa.Nodes, error) {
 func Unmarshal(p []byte) (*numa.Nodes, error) {
 	return Deserialize(p)
 }
+
+// SerializeNumaStats serializes node.NumaStats as JSON.
+func SerializeNumaStats(stats *numa.NumaStats) ([]byte, error) {
+	return json.Marshal(stats)
+}
+
+// DeserializeNumaStats takes some JSON serialized bytes and unmarshals them
+// as node.NumaStats.
+func DeserializeNumaStats(p []byte) (*numa.NumaStats, error) {
+	stats := &numa.NumaStats{}
+	err := json.Unmarshal(p, stats)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Ticker delivers the NUMA allocation counters of each node at intervals as
+// JSON serialized node.NumaStats.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: NewProfiler()}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.NumaStats()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			p, err := SerializeNumaStats(s)
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- p
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
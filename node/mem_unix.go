@@ -0,0 +1,373 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+)
+
+const (
+	MemInfoFile  = "meminfo"
+	NumaStatFile = "numastat"
+	DistanceFile = "distance"
+)
+
+// MemInfo holds a node's memory information, nodeN/meminfo. The values are
+// in kB except for the HugePages values, which are the number of huge pages.
+// Fields for keys that aren't available on the system's kernel will be 0. Any
+// key that doesn't have a field is in Extra; Extra is nil if there aren't
+// any.
+type MemInfo struct {
+	Active          uint64 `json:"active"`
+	ActiveAnon      uint64 `json:"active_anon"`
+	ActiveFile      uint64 `json:"active_file"`
+	AnonHugePages   uint64 `json:"anon_huge_pages"`
+	AnonPages       uint64 `json:"anon_pages"`
+	Bounce          uint64 `json:"bounce"`
+	Dirty           uint64 `json:"dirty"`
+	FileHugePages   uint64 `json:"file_huge_pages"`
+	FilePages       uint64 `json:"file_pages"`
+	FilePmdMapped   uint64 `json:"file_pmd_mapped"`
+	HighFree        uint64 `json:"high_free"`
+	HighTotal       uint64 `json:"high_total"`
+	HugePagesFree   uint64 `json:"huge_pages_free"`
+	HugePagesSurp   uint64 `json:"huge_pages_surp"`
+	HugePagesTotal  uint64 `json:"huge_pages_total"`
+	Inactive        uint64 `json:"inactive"`
+	InactiveAnon    uint64 `json:"inactive_anon"`
+	InactiveFile    uint64 `json:"inactive_file"`
+	KernelStack     uint64 `json:"kernel_stack"`
+	KReclaimable    uint64 `json:"k_reclaimable"`
+	LowFree         uint64 `json:"low_free"`
+	LowTotal        uint64 `json:"low_total"`
+	Mapped          uint64 `json:"mapped"`
+	MemFree         uint64 `json:"mem_free"`
+	MemTotal        uint64 `json:"mem_total"`
+	MemUsed         uint64 `json:"mem_used"`
+	Mlocked         uint64 `json:"mlocked"`
+	NFSUnstable     uint64 `json:"nfs_unstable"`
+	PageTables      uint64 `json:"page_tables"`
+	SecPageTables   uint64 `json:"sec_page_tables"`
+	ShadowCallStack uint64 `json:"shadow_call_stack"`
+	Shmem           uint64 `json:"shmem"`
+	ShmemHugePages  uint64 `json:"shmem_huge_pages"`
+	ShmemPmdMapped  uint64 `json:"shmem_pmd_mapped"`
+	Slab            uint64 `json:"slab"`
+	SReclaimable    uint64 `json:"s_reclaimable"`
+	SUnreclaim      uint64 `json:"s_unreclaim"`
+	SwapCached      uint64 `json:"swap_cached"`
+	Unaccepted      uint64 `json:"unaccepted"`
+	Unevictable     uint64 `json:"unevictable"`
+	Writeback       uint64 `json:"writeback"`
+	WritebackTmp    uint64 `json:"writeback_tmp"`
+
+	Extra map[string]uint64 `json:"extra,omitempty"`
+}
+
+// set sets the field for key to n. A key without a field is added to Extra.
+func (m *MemInfo) set(key string, n uint64) {
+	switch key {
+	case "Active":
+		m.Active = n
+	case "Active(anon)":
+		m.ActiveAnon = n
+	case "Active(file)":
+		m.ActiveFile = n
+	case "AnonHugePages":
+		m.AnonHugePages = n
+	case "AnonPages":
+		m.AnonPages = n
+	case "Bounce":
+		m.Bounce = n
+	case "Dirty":
+		m.Dirty = n
+	case "FileHugePages":
+		m.FileHugePages = n
+	case "FilePages":
+		m.FilePages = n
+	case "FilePmdMapped":
+		m.FilePmdMapped = n
+	case "HighFree":
+		m.HighFree = n
+	case "HighTotal":
+		m.HighTotal = n
+	case "HugePages_Free":
+		m.HugePagesFree = n
+	case "HugePages_Surp":
+		m.HugePagesSurp = n
+	case "HugePages_Total":
+		m.HugePagesTotal = n
+	case "Inactive":
+		m.Inactive = n
+	case "Inactive(anon)":
+		m.InactiveAnon = n
+	case "Inactive(file)":
+		m.InactiveFile = n
+	case "KernelStack":
+		m.KernelStack = n
+	case "KReclaimable":
+		m.KReclaimable = n
+	case "LowFree":
+		m.LowFree = n
+	case "LowTotal":
+		m.LowTotal = n
+	case "Mapped":
+		m.Mapped = n
+	case "MemFree":
+		m.MemFree = n
+	case "MemTotal":
+		m.MemTotal = n
+	case "MemUsed":
+		m.MemUsed = n
+	case "Mlocked":
+		m.Mlocked = n
+	case "NFS_Unstable":
+		m.NFSUnstable = n
+	case "PageTables":
+		m.PageTables = n
+	case "SecPageTables":
+		m.SecPageTables = n
+	case "ShadowCallStack":
+		m.ShadowCallStack = n
+	case "Shmem":
+		m.Shmem = n
+	case "ShmemHugePages":
+		m.ShmemHugePages = n
+	case "ShmemPmdMapped":
+		m.ShmemPmdMapped = n
+	case "Slab":
+		m.Slab = n
+	case "SReclaimable":
+		m.SReclaimable = n
+	case "SUnreclaim":
+		m.SUnreclaim = n
+	case "SwapCached":
+		m.SwapCached = n
+	case "Unaccepted":
+		m.Unaccepted = n
+	case "Unevictable":
+		m.Unevictable = n
+	case "Writeback":
+		m.Writeback = n
+	case "WritebackTmp":
+		m.WritebackTmp = n
+	default:
+		if m.Extra == nil {
+			m.Extra = make(map[string]uint64)
+		}
+		m.Extra[key] = n
+	}
+}
+
+// NumaStat holds a node's NUMA allocation counters, nodeN/numastat. The
+// counters are the number of pages and are aggregated since system boot.
+//
+// NumaHit is the number of pages that were allocated on this node as
+// intended; NumaMiss is the number of pages that were allocated on this node
+// even though the preferred node was another node; NumaForeign is the number
+// of pages that were intended for this node but were allocated on another
+// node. InterleaveHit is the number of interleave policy pages that were
+// allocated on this node as intended. LocalNode is the number of pages that
+// were allocated on this node while a process was running on it; OtherNode is
+// the number of pages that were allocated on this node while a process was
+// running on another node.
+type NumaStat struct {
+	NumaHit       uint64 `json:"numa_hit"`
+	NumaMiss      uint64 `json:"numa_miss"`
+	NumaForeign   uint64 `json:"numa_foreign"`
+	InterleaveHit uint64 `json:"interleave_hit"`
+	LocalNode     uint64 `json:"local_node"`
+	OtherNode     uint64 `json:"other_node"`
+}
+
+// MemInfo returns the memory information in the MemInfoFile of the node dir,
+// path. If the file doesn't exist, an empty MemInfo is returned.
+func (prof *Profiler) MemInfo(path string) (MemInfo, error) {
+	var m MemInfo
+	fname := filepath.Join(path, MemInfoFile)
+	b, err := ioutil.ReadFile(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return m, err
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		// Node N Key:    value kB
+		fields := strings.Fields(s.Text())
+		if len(fields) < 4 || !strings.HasSuffix(fields[2], ":") {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return m, &joe.ParseError{Info: fmt.Sprintf("%s: %s", fname, fields[2]), Err: err}
+		}
+		m.set(strings.TrimSuffix(fields[2], ":"), n)
+	}
+	return m, nil
+}
+
+// NumaStat returns the NUMA allocation counters in the NumaStatFile of the
+// node dir, path. If the file doesn't exist, an empty NumaStat is returned.
+func (prof *Profiler) NumaStat(path string) (NumaStat, error) {
+	var st NumaStat
+	fname := filepath.Join(path, NumaStatFile)
+	b, err := ioutil.ReadFile(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return st, err
+	}
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		n, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return st, &joe.ParseError{Info: fmt.Sprintf("%s: %s", fname, fields[0]), Err: err}
+		}
+		switch fields[0] {
+		case "numa_hit":
+			st.NumaHit = n
+		case "numa_miss":
+			st.NumaMiss = n
+		case "numa_foreign":
+			st.NumaForeign = n
+		case "interleave_hit":
+			st.InterleaveHit = n
+		case "local_node":
+			st.LocalNode = n
+		case "other_node":
+			st.OtherNode = n
+		}
+	}
+	return st, nil
+}
+
+// Distance returns the distances in the DistanceFile of the node dir, path.
+// If the file doesn't exist, a nil is returned.
+func (prof *Profiler) Distance(path string) ([]int32, error) {
+	fname := filepath.Join(path, DistanceFile)
+	b, err := ioutil.ReadFile(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	fields := strings.Fields(string(b))
+	d := make([]int32, len(fields))
+	for i, v := range fields {
+		n, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, &joe.ParseError{Info: fname, Err: err}
+		}
+		d[i] = int32(n)
+	}
+	return d, nil
+}
+
+// NumaStats holds the NUMA allocation counters of each node.
+type NumaStats struct {
+	Timestamp int64          `json:"timestamp"`
+	Node      []NodeNumaStat `json:"node"`
+}
+
+// NodeNumaStat holds a node's NUMA allocation counters.
+type NodeNumaStat struct {
+	ID   int32    `json:"id"`
+	Stat NumaStat `json:"stat"`
+}
+
+// NumaStats returns the NUMA allocation counters of each node. If the node
+// tree doesn't exist an os.ErrNotExist will be returned.
+func (prof *Profiler) NumaStats() (*NumaStats, error) {
+	ids, err := prof.NodeIDs()
+	if err != nil {
+		return nil, err
+	}
+	stats := &NumaStats{Timestamp: time.Now().UTC().UnixNano(), Node: make([]NodeNumaStat, 0, len(ids))}
+	for _, x := range ids {
+		p := prof.nodeXPath(x)
+		// skip nodes that went offline after the ids were read.
+		_, err = os.Stat(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		st, err := prof.NumaStat(p)
+		if err != nil {
+			return nil, err
+		}
+		stats.Node = append(stats.Node, NodeNumaStat{ID: x, Stat: st})
+	}
+	return stats, nil
+}
+
+// Ticker delivers the NUMA allocation counters of each node at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *NumaStats
+	*Profiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// data at intervals and an error channel that delivers any errors encountered.
+// Stop the ticker to signal the ticker to stop running. Stopping the ticker
+// does not close the Data channel; call Close to close both the ticker and the
+// data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *NumaStats), Profiler: NewProfiler()}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.NumaStats()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- s
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
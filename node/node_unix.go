@@ -22,11 +22,16 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 
 	"github.com/c3sr/joefriday"
 )
 
-const CPUList = "cpulist"
+const (
+	CPUList = "cpulist"
+	Online  = "online"
+)
 
 type Nodes struct {
 	Node []Node `json:"node"`
@@ -37,10 +42,17 @@ func (n *Nodes) NumaNodes() int {
 	return len(n.Node)
 }
 
-// Information about a specific node.
+// Information about a specific node. MemInfo is the node's memory
+// information, nodeN/meminfo, and NumaStat is the node's NUMA allocation
+// counters, nodeN/numastat. Distance holds the node's distance to each node,
+// in the order of the nodes, including itself; the distance to the node
+// itself is normally 10 and larger values mean slower access.
 type Node struct {
-	ID      int32  `json:"id"` // max_numa_node returns an int (in C)
-	CPUList string `json:"cpu_list"`
+	ID       int32         `json:"id"` // max_numa_node returns an int (in C)
+	CPUList  joefriday.Set `json:"cpu_list"`
+	MemInfo  MemInfo       `json:"meminfo"`
+	NumaStat NumaStat      `json:"numastat"`
+	Distance []int32       `json:"distance"`
 }
 
 // Profiler is used to process the system's sysfs node information.
@@ -68,47 +80,99 @@ func (prof *Profiler) Reset() error {
 // nil for nodes.
 func (prof *Profiler) Get() (nodes *Nodes, err error) {
 	nodes = &Nodes{}
-	var x int32 // index of nodeX currently being processed.
-
-	// First see if the node dir exists, return any error.
-	_, err = os.Stat(prof.nodePath)
+	ids, err := prof.NodeIDs()
 	if err != nil {
 		return nil, err
 	}
-	// Loop and increment x after each dir read; stop when the nodeX dir to be
-	// processed doesn't exist.
-	for {
+	for _, x := range ids {
 		var n Node
 		p := prof.nodeXPath(x)
 		// get the cpulist
 		n.ID = x
 		n.CPUList, err = prof.CPUList(p)
 		if err != nil {
-			// if the dir didn't exist; there are no more nodes to process
+			// the node went offline after the ids were read; skip it.
 			if os.IsNotExist(err) {
-				return nodes, nil
+				continue
 			}
 			// any other error will be passed back
 			return nil, err
 		}
+		n.MemInfo, err = prof.MemInfo(p)
+		if err != nil {
+			return nil, err
+		}
+		n.NumaStat, err = prof.NumaStat(p)
+		if err != nil {
+			return nil, err
+		}
+		n.Distance, err = prof.Distance(p)
+		if err != nil {
+			return nil, err
+		}
 		nodes.Node = append(nodes.Node, n)
-		x++
 	}
+	return nodes, nil
+}
+
+// NodeIDs returns the ids of the system's online nodes in ascending order.
+// Node ids aren't necessarily contiguous, e.g. memory-only nodes or offlined
+// nodes can leave gaps, so the ids are read from the node tree's online file.
+// If the online file doesn't exist, the ids of the node tree's nodeX dirs are
+// used. If the node tree doesn't exist an os.ErrNotExist will be returned.
+func (prof *Profiler) NodeIDs() ([]int32, error) {
+	// First see if the node dir exists, return any error.
+	_, err := os.Stat(prof.nodePath)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ioutil.ReadFile(filepath.Join(prof.nodePath, Online))
+	if err == nil {
+		s, err := joefriday.ParseSet(string(p))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filepath.Join(prof.nodePath, Online), err)
+		}
+		ids := make([]int32, 0, s.Count())
+		s.ForEach(func(n int) {
+			ids = append(ids, int32(n))
+		})
+		return ids, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	dirs, err := filepath.Glob(filepath.Join(prof.nodePath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+	var ids []int32
+	for _, d := range dirs {
+		n, err := strconv.Atoi(filepath.Base(d)[4:])
+		if err != nil {
+			continue // not a node dir
+		}
+		ids = append(ids, int32(n))
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
 }
 
 func (prof *Profiler) nodeXPath(x int32) string {
 	return filepath.Join(prof.nodePath, fmt.Sprintf("node%d", x))
 }
 
-// CPUList returns the string found in the CPUList file or any error that
+// CPUList returns the cpus found in the CPUList file or any error that
 // occurs.
-func (prof *Profiler) CPUList(path string) (string, error) {
+func (prof *Profiler) CPUList(path string) (joefriday.Set, error) {
 	p, err := ioutil.ReadFile(filepath.Join(path, CPUList))
 	if err != nil {
-		return "", err
+		return joefriday.Set{}, err
+	}
+	s, err := joefriday.ParseSet(string(p))
+	if err != nil {
+		return joefriday.Set{}, fmt.Errorf("%s: %s", filepath.Join(path, CPUList), err)
 	}
-	// the list is everything except for the trailing new line
-	return string(p[:len(p)-1]), nil
+	return s, nil
 }
 
 // SysFSSystemPath enables overriding the default value. This is for testing
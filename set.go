@@ -0,0 +1,312 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package joefriday
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Set is a set of cpu or node numbers. The kernel represents these either as
+// a range list, e.g. 0-3,8-11, or as a hex bitmask of comma separated 32 bit
+// words, e.g. 00000f0f (cpumap). The zero value is an empty set that is
+// ready to use.
+//
+// Sets share their members when copied: assigning a Set, or passing it by
+// value, and then calling Add or Remove on the copy can modify the original.
+// Use Clone to get an independent copy of a Set that will be modified or that
+// is returned to a caller.
+//
+// A Set is JSON encoded as a range list string. Flatbuffer schemas store a
+// Set as a range list string; use String to serialize and ParseSet to
+// deserialize it.
+type Set struct {
+	words []uint64
+}
+
+// NewSet returns a Set containing the provided numbers.
+func NewSet(v ...int) Set {
+	var s Set
+	for _, n := range v {
+		s.Add(n)
+	}
+	return s
+}
+
+// ParseSet parses a kernel range list, e.g. 0-3,8-11, and returns the
+// resulting Set. Leading and trailing white space is ignored; an empty
+// string results in an empty Set.
+func ParseSet(list string) (Set, error) {
+	var s Set
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return s, nil
+	}
+	for _, r := range strings.Split(list, ",") {
+		i := strings.IndexByte(r, '-')
+		if i < 0 {
+			n, err := strconv.Atoi(r)
+			if err != nil || n < 0 {
+				return Set{}, fmt.Errorf("set: invalid list entry %q", r)
+			}
+			s.Add(n)
+			continue
+		}
+		start, err := strconv.Atoi(r[:i])
+		if err != nil || start < 0 {
+			return Set{}, fmt.Errorf("set: invalid list range %q", r)
+		}
+		end, err := strconv.Atoi(r[i+1:])
+		if err != nil || end < start {
+			return Set{}, fmt.Errorf("set: invalid list range %q", r)
+		}
+		for n := start; n <= end; n++ {
+			s.Add(n)
+		}
+	}
+	return s, nil
+}
+
+// ParseSetMask parses a kernel hex bitmask, e.g. 00000000,0000ff0f (cpumap),
+// and returns the resulting Set. The mask is a comma separated list of 32 bit
+// words with the most significant word first. Leading and trailing white
+// space is ignored; an empty string results in an empty Set.
+func ParseSetMask(mask string) (Set, error) {
+	var s Set
+	mask = strings.TrimSpace(mask)
+	if mask == "" {
+		return s, nil
+	}
+	words := strings.Split(mask, ",")
+	for i, w := range words {
+		v, err := strconv.ParseUint(w, 16, 32)
+		if err != nil {
+			return Set{}, fmt.Errorf("set: invalid mask word %q", w)
+		}
+		base := (len(words) - 1 - i) * 32
+		for v != 0 {
+			b := bits.TrailingZeros64(v)
+			s.Add(base + b)
+			v &^= 1 << uint(b)
+		}
+	}
+	return s, nil
+}
+
+// Clone returns a copy of the set that doesn't share its members with s.
+func (s Set) Clone() Set {
+	if s.words == nil {
+		return Set{}
+	}
+	c := Set{words: make([]uint64, len(s.words))}
+	copy(c.words, s.words)
+	return c
+}
+
+// Add adds n to the set. Negative numbers are ignored.
+func (s *Set) Add(n int) {
+	if n < 0 {
+		return
+	}
+	i := n / 64
+	for len(s.words) <= i {
+		s.words = append(s.words, 0)
+	}
+	s.words[i] |= 1 << uint(n%64)
+}
+
+// Remove removes n from the set.
+func (s *Set) Remove(n int) {
+	if !s.Has(n) {
+		return
+	}
+	s.words[n/64] &^= 1 << uint(n%64)
+}
+
+// Has returns whether or not n is in the set.
+func (s Set) Has(n int) bool {
+	if n < 0 || n/64 >= len(s.words) {
+		return false
+	}
+	return s.words[n/64]&(1<<uint(n%64)) != 0
+}
+
+// Count returns the number of members in the set.
+func (s Set) Count() int {
+	var cnt int
+	for _, w := range s.words {
+		cnt += bits.OnesCount64(w)
+	}
+	return cnt
+}
+
+// IsEmpty returns whether or not the set has any members.
+func (s Set) IsEmpty() bool {
+	for _, w := range s.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Members returns the members of the set in ascending order.
+func (s Set) Members() []int {
+	m := make([]int, 0, s.Count())
+	s.ForEach(func(n int) {
+		m = append(m, n)
+	})
+	return m
+}
+
+// ForEach calls f for each member of the set in ascending order.
+func (s Set) ForEach(f func(n int)) {
+	for i, w := range s.words {
+		for w != 0 {
+			b := bits.TrailingZeros64(w)
+			f(i*64 + b)
+			w &^= 1 << uint(b)
+		}
+	}
+}
+
+// Union returns a new Set containing the members of both s and o.
+func (s Set) Union(o Set) Set {
+	a, b := s.words, o.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	u := Set{words: make([]uint64, len(a))}
+	copy(u.words, a)
+	for i, w := range b {
+		u.words[i] |= w
+	}
+	return u
+}
+
+// Intersection returns a new Set containing the members that are in both s
+// and o.
+func (s Set) Intersection(o Set) Set {
+	n := len(s.words)
+	if len(o.words) < n {
+		n = len(o.words)
+	}
+	x := Set{words: make([]uint64, n)}
+	for i := 0; i < n; i++ {
+		x.words[i] = s.words[i] & o.words[i]
+	}
+	return x
+}
+
+// Equal returns whether or not s and o have the same members.
+func (s Set) Equal(o Set) bool {
+	a, b := s.words, o.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for i := range a {
+		var w uint64
+		if i < len(b) {
+			w = b[i]
+		}
+		if a[i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the set as a kernel range list, e.g. 0-3,8-11. An empty set
+// results in an empty string.
+func (s Set) String() string {
+	var b strings.Builder
+	start, prev := -1, -1
+	flush := func() {
+		if start < 0 {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(start))
+		if prev > start {
+			b.WriteByte('-')
+			b.WriteString(strconv.Itoa(prev))
+		}
+	}
+	s.ForEach(func(n int) {
+		if n == prev+1 && start >= 0 {
+			prev = n
+			return
+		}
+		flush()
+		start, prev = n, n
+	})
+	flush()
+	return b.String()
+}
+
+// Mask returns the set as a kernel hex bitmask, e.g. 00000000,0000ff0f; the
+// mask is a comma separated list of 32 bit words with the most significant
+// word first. Only as many words as are needed to hold the set's highest
+// member are used; an empty set results in a single 0 word.
+func (s Set) Mask() string {
+	n := len(s.words) * 2
+	for n > 1 && s.word32(n-1) == 0 {
+		n--
+	}
+	if n == 0 {
+		n = 1
+	}
+	var b strings.Builder
+	for i := n - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%08x", s.word32(i))
+		if i > 0 {
+			b.WriteByte(',')
+		}
+	}
+	return b.String()
+}
+
+// word32 returns the i'th 32 bit word of the set.
+func (s Set) word32(i int) uint32 {
+	if i/2 >= len(s.words) {
+		return 0
+	}
+	return uint32(s.words[i/2] >> uint(32*(i%2)))
+}
+
+// MarshalJSON encodes the set as a JSON string containing the set's range
+// list.
+func (s Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a JSON string containing a range list into the set.
+func (s *Set) UnmarshalJSON(p []byte) error {
+	var list string
+	err := json.Unmarshal(p, &list)
+	if err != nil {
+		return err
+	}
+	v, err := ParseSet(list)
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
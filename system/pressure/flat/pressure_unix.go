@@ -0,0 +1,285 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pressure gets Pressure Stall Information (PSI) from the
+// /proc/pressure files. Instead of returning a Go struct, it returns
+// Flatbuffer serialized bytes. Functions to deserialize the Flatbuffer
+// serialized bytes into pressure.Pressure and pressure.Stalls structs are
+// provided.
+//
+// Note: the package name is pressure and not the final element of the import
+// path (flat).
+package pressure
+
+import (
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	p "github.com/c3sr/joefriday/system/pressure"
+	"github.com/c3sr/joefriday/system/pressure/flat/structs"
+	fb "github.com/google/flatbuffers/go"
+)
+
+// Profiler is used to process the pressure information, /proc/pressure,
+// using Flatbuffers.
+type Profiler struct {
+	*p.Profiler
+	*fb.Builder
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	pp, err := p.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: pp, Builder: fb.NewBuilder(0)}, nil
+}
+
+// Get returns the current pressure information as Flatbuffer serialized
+// bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	pr, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(pr), nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current pressure information as Flatbuffer serialized
+// bytes using the package's global Profiler.
+func Get() (b []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		std.Builder.Reset()
+	}
+	return std.Get()
+}
+
+// Serialize serializes pressure information using Flatbuffers.
+func (prof *Profiler) Serialize(pr p.Pressure) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	cpu := serializeResource(prof.Builder, &pr.CPU)
+	mem := serializeResource(prof.Builder, &pr.Memory)
+	io := serializeResource(prof.Builder, &pr.IO)
+	irq := serializeResource(prof.Builder, &pr.IRQ)
+	structs.PressureStart(prof.Builder)
+	structs.PressureAddTimestamp(prof.Builder, pr.Timestamp)
+	structs.PressureAddCPU(prof.Builder, cpu)
+	structs.PressureAddMemory(prof.Builder, mem)
+	structs.PressureAddIO(prof.Builder, io)
+	structs.PressureAddIRQ(prof.Builder, irq)
+	prof.Builder.Finish(structs.PressureEnd(prof.Builder))
+	return copyBytes(prof.Builder)
+}
+
+// serializeResource serializes a resource's pressure information and
+// returns the resulting UOffsetT.
+func serializeResource(bldr *fb.Builder, r *p.Resource) fb.UOffsetT {
+	some := serializeStall(bldr, &r.Some)
+	full := serializeStall(bldr, &r.Full)
+	structs.ResourceStart(bldr)
+	structs.ResourceAddAvailable(bldr, r.Available)
+	structs.ResourceAddHasSome(bldr, r.HasSome)
+	structs.ResourceAddHasFull(bldr, r.HasFull)
+	structs.ResourceAddSome(bldr, some)
+	structs.ResourceAddFull(bldr, full)
+	return structs.ResourceEnd(bldr)
+}
+
+// serializeStall serializes a some or full line and returns the resulting
+// UOffsetT.
+func serializeStall(bldr *fb.Builder, s *p.Stall) fb.UOffsetT {
+	structs.StallStart(bldr)
+	structs.StallAddAvg10(bldr, s.Avg10)
+	structs.StallAddAvg60(bldr, s.Avg60)
+	structs.StallAddAvg300(bldr, s.Avg300)
+	structs.StallAddTotal(bldr, s.Total)
+	return structs.StallEnd(bldr)
+}
+
+// Serialize serializes pressure information using Flatbuffers with the
+// package's global Profiler.
+func Serialize(pr p.Pressure) (b []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(pr), nil
+}
+
+// Deserialize takes some Flatbuffer serialized bytes and deserializes them as
+// pressure.Pressure.
+func Deserialize(b []byte) p.Pressure {
+	flatP := structs.GetRootAsPressure(b, 0)
+	var pr p.Pressure
+	r := &structs.Resource{}
+	s := &structs.Stall{}
+	pr.Timestamp = flatP.Timestamp()
+	deserializeResource(flatP.CPU(r), s, &pr.CPU)
+	deserializeResource(flatP.Memory(r), s, &pr.Memory)
+	deserializeResource(flatP.IO(r), s, &pr.IO)
+	deserializeResource(flatP.IRQ(r), s, &pr.IRQ)
+	return pr
+}
+
+// deserializeResource deserializes a resource; s is used to access the
+// resource's some and full lines.
+func deserializeResource(flatR *structs.Resource, s *structs.Stall, r *p.Resource) {
+	if flatR == nil {
+		return
+	}
+	r.Available = flatR.Available()
+	r.HasSome = flatR.HasSome()
+	r.HasFull = flatR.HasFull()
+	if flatR.Some(s) != nil {
+		deserializeStall(s, &r.Some)
+	}
+	if flatR.Full(s) != nil {
+		deserializeStall(s, &r.Full)
+	}
+}
+
+// deserializeStall deserializes a some or full line.
+func deserializeStall(flatS *structs.Stall, s *p.Stall) {
+	s.Avg10 = flatS.Avg10()
+	s.Avg60 = flatS.Avg60()
+	s.Avg300 = flatS.Avg300()
+	s.Total = flatS.Total()
+}
+
+// SerializeStalls serializes the stall time of each resource using
+// Flatbuffers.
+func (prof *Profiler) SerializeStalls(s *p.Stalls) []byte {
+	// ensure the Builder is in a usable state.
+	prof.Builder.Reset()
+	cpu := serializeStallTime(prof.Builder, &s.CPU)
+	mem := serializeStallTime(prof.Builder, &s.Memory)
+	io := serializeStallTime(prof.Builder, &s.IO)
+	irq := serializeStallTime(prof.Builder, &s.IRQ)
+	structs.StallsStart(prof.Builder)
+	structs.StallsAddTimestamp(prof.Builder, s.Timestamp)
+	structs.StallsAddTimeDelta(prof.Builder, s.TimeDelta)
+	structs.StallsAddCPU(prof.Builder, cpu)
+	structs.StallsAddMemory(prof.Builder, mem)
+	structs.StallsAddIO(prof.Builder, io)
+	structs.StallsAddIRQ(prof.Builder, irq)
+	prof.Builder.Finish(structs.StallsEnd(prof.Builder))
+	return copyBytes(prof.Builder)
+}
+
+// serializeStallTime serializes a resource's stall time and returns the
+// resulting UOffsetT.
+func serializeStallTime(bldr *fb.Builder, st *p.StallTime) fb.UOffsetT {
+	structs.StallTimeStart(bldr)
+	structs.StallTimeAddSome(bldr, st.Some)
+	structs.StallTimeAddFull(bldr, st.Full)
+	structs.StallTimeAddSomePercent(bldr, st.SomePercent)
+	structs.StallTimeAddFullPercent(bldr, st.FullPercent)
+	return structs.StallTimeEnd(bldr)
+}
+
+// DeserializeStalls takes some Flatbuffer serialized bytes and deserializes
+// them as pressure.Stalls.
+func DeserializeStalls(b []byte) *p.Stalls {
+	flatS := structs.GetRootAsStalls(b, 0)
+	s := &p.Stalls{Timestamp: flatS.Timestamp(), TimeDelta: flatS.TimeDelta()}
+	st := &structs.StallTime{}
+	deserializeStallTime(flatS.CPU(st), &s.CPU)
+	deserializeStallTime(flatS.Memory(st), &s.Memory)
+	deserializeStallTime(flatS.IO(st), &s.IO)
+	deserializeStallTime(flatS.IRQ(st), &s.IRQ)
+	return s
+}
+
+// deserializeStallTime deserializes a resource's stall time.
+func deserializeStallTime(flatST *structs.StallTime, st *p.StallTime) {
+	if flatST == nil {
+		return
+	}
+	st.Some = flatST.Some()
+	st.Full = flatST.Full()
+	st.SomePercent = flatST.SomePercent()
+	st.FullPercent = flatST.FullPercent()
+}
+
+// copyBytes returns a copy of the builder's finished bytes (otherwise they
+// get lost in reset).
+func copyBytes(bldr *fb.Builder) []byte {
+	b := bldr.Bytes[bldr.Head():]
+	tmp := make([]byte, len(b))
+	copy(tmp, b)
+	return tmp
+}
+
+// Ticker delivers the stall time of each resource at intervals as Flatbuffer
+// serialized pressure.Stalls.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*Profiler
+	stall *p.StallProfiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// stall time for each interval and an error channel that delivers any errors
+// encountered. Stop the ticker to signal the ticker to stop running.
+// Stopping the ticker does not close the Data channel; call Close to close
+// both the ticker and the data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	sp, err := p.NewStallProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), Profiler: &Profiler{Profiler: sp.Profiler, Builder: fb.NewBuilder(0)}, stall: sp}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.stall.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- t.SerializeStalls(s)
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
@@ -0,0 +1,181 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pressure gets Pressure Stall Information (PSI) from the
+// /proc/pressure files. Instead of returning a Go struct, it returns JSON
+// serialized bytes. Functions to deserialize the JSON serialized bytes into
+// pressure.Pressure and pressure.Stalls structs are provided.
+//
+// Note: the package name is pressure and not the final element of the import
+// path (json).
+package pressure
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	p "github.com/c3sr/joefriday/system/pressure"
+)
+
+// Profiler is used to process the pressure information, /proc/pressure,
+// using JSON.
+type Profiler struct {
+	*p.Profiler
+}
+
+// Returns an initialized Profiler; ready to use.
+func NewProfiler() (prof *Profiler, err error) {
+	pp, err := p.NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	return &Profiler{Profiler: pp}, nil
+}
+
+// Get returns the current pressure information as JSON serialized bytes.
+func (prof *Profiler) Get() ([]byte, error) {
+	pr, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	return prof.Serialize(pr)
+}
+
+var std *Profiler
+var stdMu sync.Mutex //protects standard to prevent a data race on checking/instantiation
+
+// Get returns the current pressure information as JSON serialized bytes
+// using the package's global Profiler.
+func Get() (b []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Get()
+}
+
+// Serialize pressure.Pressure using JSON.
+func (prof *Profiler) Serialize(pr p.Pressure) ([]byte, error) {
+	return json.Marshal(pr)
+}
+
+// Serialize pressure.Pressure using JSON with the package's global Profiler.
+func Serialize(pr p.Pressure) (b []byte, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return std.Serialize(pr)
+}
+
+// Marshal is an alias for Serialize.
+func (prof *Profiler) Marshal(pr p.Pressure) ([]byte, error) {
+	return prof.Serialize(pr)
+}
+
+// Marshal is an alias for Serialize using the package's global profiler.
+func Marshal(pr p.Pressure) ([]byte, error) {
+	return Serialize(pr)
+}
+
+// Deserialize takes some JSON serialized bytes and unmarshals them as
+// pressure.Pressure.
+func Deserialize(b []byte) (pr p.Pressure, err error) {
+	err = json.Unmarshal(b, &pr)
+	if err != nil {
+		return pr, err
+	}
+	return pr, nil
+}
+
+// Unmarshal is an alias for Deserialize.
+func Unmarshal(b []byte) (p.Pressure, error) {
+	return Deserialize(b)
+}
+
+// SerializeStalls serializes pressure.Stalls using JSON.
+func SerializeStalls(s *p.Stalls) ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// DeserializeStalls takes some JSON serialized bytes and unmarshals them as
+// pressure.Stalls.
+func DeserializeStalls(b []byte) (*p.Stalls, error) {
+	var s p.Stalls
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Ticker delivers the stall time of each resource at intervals as JSON
+// serialized pressure.Stalls.
+type Ticker struct {
+	*joe.Ticker
+	Data chan []byte
+	*p.StallProfiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// stall time for each interval and an error channel that delivers any errors
+// encountered. Stop the ticker to signal the ticker to stop running.
+// Stopping the ticker does not close the Data channel; call Close to close
+// both the ticker and the data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	sp, err := p.NewStallProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan []byte), StallProfiler: sp}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			b, err := SerializeStalls(s)
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- b
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
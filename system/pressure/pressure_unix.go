@@ -0,0 +1,424 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pressure gets Pressure Stall Information (PSI) from the
+// /proc/pressure files: cpu, memory, io, and irq. For each resource, some is
+// the share of time in which at least some tasks were stalled on the
+// resource and full is the share of time in which all non-idle tasks were
+// stalled on the resource simultaneously.
+//
+// Not every kernel provides every resource: PSI was added in 4.20, cpu full
+// in 5.13, and irq, which only has full, in 6.1. A resource that isn't
+// provided isn't Available.
+//
+// Stalls, the stall time during an interval, is calculated from the total
+// stall time of two Pressure snapshots.
+//
+// A Monitor delivers events when a resource's stall time exceeds a Trigger's
+// threshold within its window. Triggers are registered with the kernel; when
+// that isn't permitted, they are emulated from the polled totals.
+package pressure
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	joe "github.com/c3sr/joefriday"
+	"github.com/c3sr/joefriday/helpers"
+)
+
+// ProcPressure is the path to the pressure files.
+const ProcPressure = "/proc/pressure"
+
+// The resources; these are the names of the files in /proc/pressure.
+const (
+	CPU    = "cpu"
+	Memory = "memory"
+	IO     = "io"
+	IRQ    = "irq"
+)
+
+// Resources are the names of the resources that pressure information is
+// provided for, in the order that they are read.
+var Resources = [...]string{CPU, Memory, IO, IRQ}
+
+// Pressure holds the pressure information for each resource.
+type Pressure struct {
+	Timestamp int64    `json:"timestamp"`
+	CPU       Resource `json:"cpu"`
+	Memory    Resource `json:"memory"`
+	IO        Resource `json:"io"`
+	IRQ       Resource `json:"irq"`
+}
+
+// Resource returns the pressure information for the named resource. A nil is
+// returned if the name isn't a resource.
+func (p *Pressure) Resource(name string) *Resource {
+	switch name {
+	case CPU:
+		return &p.CPU
+	case Memory:
+		return &p.Memory
+	case IO:
+		return &p.IO
+	case IRQ:
+		return &p.IRQ
+	}
+	return nil
+}
+
+// Resource holds the pressure information for a resource. Available is false
+// if the kernel doesn't provide the resource's information. HasSome and
+// HasFull are whether or not the kernel provides some and full information,
+// respectively, for the resource.
+type Resource struct {
+	Available bool  `json:"available"`
+	HasSome   bool  `json:"has_some"`
+	HasFull   bool  `json:"has_full"`
+	Some      Stall `json:"some"`
+	Full      Stall `json:"full"`
+}
+
+// Stall holds a some or full line of a pressure file. Avg10, Avg60, and
+// Avg300 are the percentage of time stalled over the last 10, 60, and 300
+// seconds. Total is the total time stalled, in microseconds.
+type Stall struct {
+	Avg10  float32 `json:"avg10"`
+	Avg60  float32 `json:"avg60"`
+	Avg300 float32 `json:"avg300"`
+	Total  int64   `json:"total"`
+}
+
+// Profiler processes the pressure files.
+type Profiler struct {
+	*joe.Buffer
+	procPressurePath string
+	procs            [len(Resources)]joe.Procer // nil if the resource isn't available
+}
+
+// Returns an initialized Profiler; ready to use. An error is returned if the
+// system doesn't provide pressure information.
+func NewProfiler() (prof *Profiler, err error) {
+	prof = &Profiler{Buffer: joe.NewBuffer()}
+	err = prof.ProcPressurePath(ProcPressure)
+	if err != nil {
+		return nil, err
+	}
+	return prof, nil
+}
+
+// ProcPressurePath sets the path to the pressure files and opens the files
+// of the available resources; any previously opened files are closed. An
+// error is returned if none of the resources are available.
+//
+// This is for testing and should not be used outside of tests.
+func (prof *Profiler) ProcPressurePath(s string) error {
+	prof.Close()
+	prof.procPressurePath = s
+	var n int
+	for i, name := range Resources {
+		proc, err := joe.NewProc(filepath.Join(s, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			prof.Close()
+			return err
+		}
+		prof.procs[i] = proc
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("pressure: %s: no pressure information available", s)
+	}
+	return nil
+}
+
+// Close closes the pressure files.
+func (prof *Profiler) Close() error {
+	var err error
+	for i, proc := range prof.procs {
+		if proc == nil {
+			continue
+		}
+		if c, ok := proc.(io.Closer); ok {
+			if cerr := c.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+		prof.procs[i] = nil
+	}
+	return err
+}
+
+// Path returns the path to the named resource's pressure file.
+func (prof *Profiler) Path(name string) string {
+	return filepath.Join(prof.procPressurePath, name)
+}
+
+// Available returns whether or not the named resource's pressure information
+// is available.
+func (prof *Profiler) Available(name string) bool {
+	for i := range Resources {
+		if Resources[i] == name {
+			return prof.procs[i] != nil
+		}
+	}
+	return false
+}
+
+// Get returns the current pressure information.
+func (prof *Profiler) Get() (p Pressure, err error) {
+	p.Timestamp = time.Now().UTC().UnixNano()
+	for i, name := range Resources {
+		if prof.procs[i] == nil {
+			continue
+		}
+		err = prof.resource(prof.procs[i], p.Resource(name))
+		if err != nil {
+			return p, fmt.Errorf("%s: %s", name, err)
+		}
+	}
+	return p, nil
+}
+
+var (
+	some = []byte("some")
+	full = []byte("full")
+)
+
+// resource reads a resource's pressure file.
+func (prof *Profiler) resource(proc joe.Procer, r *Resource) error {
+	err := proc.Reset()
+	if err != nil {
+		return err
+	}
+	r.Available = true
+	for {
+		prof.Line, err = proc.ReadSlice('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return &joe.ReadError{Err: err}
+		}
+		fields := bytes.Fields(prof.Line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch {
+		case bytes.Equal(fields[0], some):
+			r.HasSome = true
+			err = parseStall(fields[1:], &r.Some)
+		case bytes.Equal(fields[0], full):
+			r.HasFull = true
+			err = parseStall(fields[1:], &r.Full)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseStall parses the key=value fields of a some or full line.
+func parseStall(fields [][]byte, s *Stall) error {
+	for _, f := range fields {
+		i := bytes.IndexByte(f, '=')
+		if i < 0 {
+			return &joe.ParseError{Info: string(f), Err: errors.New("expected key=value")}
+		}
+		key, v := string(f[:i]), f[i+1:]
+		if key == "total" {
+			n, err := helpers.ParseUint(v)
+			if err != nil {
+				return &joe.ParseError{Info: key, Err: err}
+			}
+			s.Total = int64(n)
+			continue
+		}
+		x, err := strconv.ParseFloat(string(v), 32)
+		if err != nil {
+			return &joe.ParseError{Info: key, Err: err}
+		}
+		switch key {
+		case "avg10":
+			s.Avg10 = float32(x)
+		case "avg60":
+			s.Avg60 = float32(x)
+		case "avg300":
+			s.Avg300 = float32(x)
+		}
+	}
+	return nil
+}
+
+var std *Profiler
+var stdMu sync.Mutex
+
+// Get gets the pressure information using the package's global Profiler.
+func Get() (p Pressure, err error) {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std, err = NewProfiler()
+		if err != nil {
+			return p, err
+		}
+	}
+	return std.Get()
+}
+
+// Stalls holds the stall time of each resource between two Pressure
+// snapshots. TimeDelta is in nanoseconds.
+type Stalls struct {
+	Timestamp int64     `json:"timestamp"`
+	TimeDelta int64     `json:"time_delta"`
+	CPU       StallTime `json:"cpu"`
+	Memory    StallTime `json:"memory"`
+	IO        StallTime `json:"io"`
+	IRQ       StallTime `json:"irq"`
+}
+
+// StallTime holds a resource's stall time during the interval. Some and Full
+// are in microseconds. SomePercent and FullPercent are the stall time as a
+// percentage of the interval.
+type StallTime struct {
+	Some        int64   `json:"some"`
+	Full        int64   `json:"full"`
+	SomePercent float32 `json:"some_percent"`
+	FullPercent float32 `json:"full_percent"`
+}
+
+// CalculateStalls returns the stall time of each resource between two
+// Pressure snapshots. If a total is lower than its prior value, the total is
+// assumed to have been reset and the current value is used as the delta.
+func CalculateStalls(prior, cur *Pressure) *Stalls {
+	s := &Stalls{Timestamp: cur.Timestamp, TimeDelta: cur.Timestamp - prior.Timestamp}
+	if s.TimeDelta <= 0 {
+		return s
+	}
+	us := float32(s.TimeDelta) / float32(time.Microsecond)
+	s.CPU = calculateStallTime(&prior.CPU, &cur.CPU, us)
+	s.Memory = calculateStallTime(&prior.Memory, &cur.Memory, us)
+	s.IO = calculateStallTime(&prior.IO, &cur.IO, us)
+	s.IRQ = calculateStallTime(&prior.IRQ, &cur.IRQ, us)
+	return s
+}
+
+// calculateStallTime calculates the stall time of a resource during an
+// interval of us microseconds.
+func calculateStallTime(prior, cur *Resource, us float32) StallTime {
+	var st StallTime
+	st.Some = delta(prior.Some.Total, cur.Some.Total)
+	st.Full = delta(prior.Full.Total, cur.Full.Total)
+	st.SomePercent = float32(st.Some) / us * 100
+	st.FullPercent = float32(st.Full) / us * 100
+	return st
+}
+
+// delta returns the difference between two totals. If the current total is
+// lower than the prior, the total started over and the current total is the
+// delta.
+func delta(prior, cur int64) int64 {
+	if cur < prior {
+		return cur
+	}
+	return cur - prior
+}
+
+// StallProfiler is used to process the stall time of each resource; the last
+// Pressure snapshot is kept so that the stall time can be calculated.
+type StallProfiler struct {
+	*Profiler
+	prior Pressure
+}
+
+// Returns an initialized StallProfiler; ready to use. The initial Pressure
+// snapshot is taken during initialization.
+func NewStallProfiler() (prof *StallProfiler, err error) {
+	p, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	prior, err := p.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &StallProfiler{Profiler: p, prior: prior}, nil
+}
+
+// Get returns the stall time of each resource since the last time the
+// StallProfiler was used. If this is the first use, the stall time since the
+// StallProfiler was created is returned.
+func (prof *StallProfiler) Get() (s *Stalls, err error) {
+	cur, err := prof.Profiler.Get()
+	if err != nil {
+		return nil, err
+	}
+	s = CalculateStalls(&prof.prior, &cur)
+	prof.prior = cur
+	return s, nil
+}
+
+// Ticker delivers the stall time of each resource at intervals.
+type Ticker struct {
+	*joe.Ticker
+	Data chan *Stalls
+	*StallProfiler
+}
+
+// NewTicker returns a new Ticker containing a Data channel that delivers the
+// stall time for each interval and an error channel that delivers any errors
+// encountered. Stop the ticker to signal the ticker to stop running.
+// Stopping the ticker does not close the Data channel; call Close to close
+// both the ticker and the data channel.
+func NewTicker(d time.Duration) (joe.Tocker, error) {
+	p, err := NewStallProfiler()
+	if err != nil {
+		return nil, err
+	}
+	t := Ticker{Ticker: joe.NewTicker(d), Data: make(chan *Stalls), StallProfiler: p}
+	go t.Run()
+	return &t, nil
+}
+
+// Run runs the ticker.
+func (t *Ticker) Run() {
+	for {
+		select {
+		case <-t.Done:
+			return
+		case <-t.C:
+			s, err := t.Get()
+			if err != nil {
+				t.Errs <- err
+				continue
+			}
+			t.Data <- s
+		}
+	}
+}
+
+// Close closes the ticker resources.
+func (t *Ticker) Close() {
+	t.Ticker.Close()
+	close(t.Data)
+}
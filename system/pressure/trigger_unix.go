@@ -0,0 +1,458 @@
+// Copyright 2016 Joel Scoble and The JoeFriday authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressure
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// The kinds of stall a Trigger can be for.
+const (
+	Some = "some"
+	Full = "full"
+)
+
+// The kernel's limits on a trigger's window.
+const (
+	MinWindow = 500 * time.Millisecond
+	MaxWindow = 10 * time.Second
+)
+
+// pollTimeout is the longest a Monitor waits for a kernel trigger before
+// checking whether it has been stopped.
+const pollTimeout = 100 * time.Millisecond
+
+// Trigger is a pressure threshold on a resource: an event is triggered when
+// the resource's Kind, some or full, stall time exceeds Threshold within a
+// Window. At most one event per Window is triggered.
+type Trigger struct {
+	Resource  string        `json:"resource"`
+	Kind      string        `json:"kind"`
+	Threshold time.Duration `json:"threshold"`
+	Window    time.Duration `json:"window"`
+}
+
+// String returns the trigger in the format that is written to the
+// resource's pressure file, e.g. "some 150000 1000000"; the threshold and
+// window are in microseconds.
+func (t Trigger) String() string {
+	return fmt.Sprintf("%s %d %d", t.Kind, t.Threshold/time.Microsecond, t.Window/time.Microsecond)
+}
+
+// Validate returns an error if the trigger isn't valid.
+func (t Trigger) Validate() error {
+	var p Pressure
+	if p.Resource(t.Resource) == nil {
+		return fmt.Errorf("pressure trigger: unknown resource %q", t.Resource)
+	}
+	if t.Kind != Some && t.Kind != Full {
+		return fmt.Errorf("pressure trigger: unknown kind %q", t.Kind)
+	}
+	if t.Window < MinWindow || t.Window > MaxWindow {
+		return fmt.Errorf("pressure trigger: window %s must be between %s and %s", t.Window, MinWindow, MaxWindow)
+	}
+	if t.Threshold <= 0 || t.Threshold > t.Window {
+		return fmt.Errorf("pressure trigger: threshold %s must be greater than 0 and no more than the window", t.Threshold)
+	}
+	return nil
+}
+
+// Event is a triggered Trigger. Emulated is whether or not the event was
+// detected from the polled totals instead of by the kernel. For emulated
+// events, Stall is the stall time within the window, in microseconds; the
+// kernel doesn't report it, so it is 0 for kernel events.
+type Event struct {
+	Timestamp int64   `json:"timestamp"`
+	Trigger   Trigger `json:"trigger"`
+	Emulated  bool    `json:"emulated"`
+	Stall     int64   `json:"stall"`
+}
+
+// Monitor delivers pressure trigger events. Triggers are registered with the
+// kernel by writing them to the resource's pressure file; if that isn't
+// permitted, e.g. the process lacks CAP_SYS_RESOURCE or the kernel doesn't
+// support triggers, the trigger is emulated by polling the resource's total
+// stall time.
+//
+// Unprivileged processes can only register triggers whose window is a
+// multiple of 2 seconds; other triggers are emulated. A trigger whose
+// resource doesn't provide its kind, e.g. some irq pressure, is an error.
+//
+// Like joefriday.Ticker, errors are delivered on Errs, and Done is used to
+// stop the Monitor. Stop the monitor to signal it to stop running; call Run
+// to restart it. Close closes the Events and Errs channels and unregisters
+// the triggers.
+type Monitor struct {
+	Events   chan Event
+	Errs     chan error
+	Done     chan struct{}
+	prof     *Profiler
+	ownsProf bool // whether or not the profiler is closed with the monitor
+	kernel   []kernelTrigger
+	emulated []emulatedTrigger
+	period   time.Duration // how often the emulated triggers' totals are polled
+	mu       sync.Mutex
+	running  bool
+	closed   bool
+	stopped  chan struct{} // Run signals that it has stopped running
+}
+
+// kernelTrigger is a trigger registered with the kernel; fd is the open
+// pressure file that the trigger was written to.
+type kernelTrigger struct {
+	Trigger
+	fd int
+}
+
+// emulatedTrigger is a trigger detected from the polled totals. The samples
+// cover the trigger's window; last is when the last event was triggered.
+type emulatedTrigger struct {
+	Trigger
+	samples []sample
+	last    int64
+}
+
+// sample is a total, in microseconds, at a time, in nanoseconds.
+type sample struct {
+	ts    int64
+	total int64
+}
+
+// NewMonitor returns a running Monitor for the triggers. See Monitor.
+func NewMonitor(triggers ...Trigger) (*Monitor, error) {
+	prof, err := NewProfiler()
+	if err != nil {
+		return nil, err
+	}
+	m, err := prof.Monitor(triggers...)
+	if err != nil {
+		prof.Close()
+		return nil, err
+	}
+	m.mu.Lock()
+	m.ownsProf = true
+	m.mu.Unlock()
+	return m, nil
+}
+
+// Monitor returns a running Monitor for the triggers that uses the profiler
+// to read the pressure files; the profiler must not be used elsewhere while
+// the Monitor is in use. See Monitor.
+func (prof *Profiler) Monitor(triggers ...Trigger) (*Monitor, error) {
+	if len(triggers) == 0 {
+		return nil, errors.New("pressure monitor: no triggers")
+	}
+	// the current pressure is used to check that each resource provides the
+	// trigger's kind; e.g. irq doesn't provide some and, before Linux 5.13,
+	// cpu doesn't provide full.
+	p, err := prof.Get()
+	if err != nil {
+		return nil, err
+	}
+	m := &Monitor{Events: make(chan Event), Errs: make(chan error), Done: make(chan struct{}), prof: prof, stopped: make(chan struct{}, 1)}
+	for _, t := range triggers {
+		err := t.Validate()
+		if err != nil {
+			m.unregister()
+			return nil, err
+		}
+		if !prof.Available(t.Resource) {
+			m.unregister()
+			return nil, fmt.Errorf("pressure monitor: %s pressure is not available", t.Resource)
+		}
+		r := p.Resource(t.Resource)
+		if (t.Kind == Some && !r.HasSome) || (t.Kind == Full && !r.HasFull) {
+			m.unregister()
+			return nil, fmt.Errorf("pressure monitor: %s pressure doesn't provide %s", t.Resource, t.Kind)
+		}
+		fd, err := m.register(t)
+		if err == nil {
+			m.kernel = append(m.kernel, kernelTrigger{Trigger: t, fd: fd})
+			continue
+		}
+		if !notPermitted(t, err) {
+			m.unregister()
+			return nil, fmt.Errorf("pressure monitor: %s: %s", t, err)
+		}
+		m.emulated = append(m.emulated, emulatedTrigger{Trigger: t})
+		// the totals are polled often enough to detect the threshold being
+		// exceeded within a tenth of the shortest window.
+		if m.period == 0 || t.Window/10 < m.period {
+			m.period = t.Window / 10
+		}
+	}
+	go m.Run()
+	return m, nil
+}
+
+// Emulated returns the triggers that are emulated instead of being
+// registered with the kernel.
+func (m *Monitor) Emulated() []Trigger {
+	t := make([]Trigger, len(m.emulated))
+	for i := range m.emulated {
+		t[i] = m.emulated[i].Trigger
+	}
+	return t
+}
+
+// register writes the trigger to the resource's pressure file; the open file
+// is returned.
+func (m *Monitor) register(t Trigger) (int, error) {
+	fd, err := syscall.Open(m.prof.Path(t.Resource), syscall.O_RDWR|syscall.O_NONBLOCK|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, err
+	}
+	// the kernel expects the trigger to be null terminated.
+	_, err = syscall.Write(fd, append([]byte(t.String()), 0))
+	if err != nil {
+		syscall.Close(fd)
+		return -1, err
+	}
+	return fd, nil
+}
+
+// unregister closes the pressure files of the kernel triggers, which
+// unregisters them.
+func (m *Monitor) unregister() {
+	for i := range m.kernel {
+		syscall.Close(m.kernel[i].fd)
+	}
+	m.kernel = nil
+}
+
+// notPermitted returns whether or not the error from registering the trigger
+// means that the trigger isn't permitted. Triggers are validated before being
+// registered, so EINVAL is the kernel's restriction on unprivileged triggers,
+// their window must be a multiple of 2 seconds, but only if the trigger's
+// window isn't one; otherwise it's a real error.
+func notPermitted(t Trigger, err error) bool {
+	switch err {
+	case syscall.EACCES, syscall.EPERM, syscall.EROFS, syscall.EOPNOTSUPP:
+		return true
+	case syscall.EINVAL:
+		return t.Window%(2*time.Second) != 0
+	}
+	return false
+}
+
+// Run runs the monitor.
+func (m *Monitor) Run() {
+	m.mu.Lock()
+	if m.closed || m.running {
+		m.mu.Unlock()
+		return
+	}
+	m.running = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.running = false
+		if m.closed {
+			m.close()
+		}
+		m.mu.Unlock()
+		// let Stop know that the monitor can be restarted; the channel is
+		// buffered so this doesn't block if the monitor was closed instead.
+		select {
+		case m.stopped <- struct{}{}:
+		default:
+		}
+	}()
+
+	fds := make([]pollFd, len(m.kernel))
+	for i := range m.kernel {
+		fds[i] = pollFd{fd: int32(m.kernel[i].fd), events: pollPri}
+	}
+	timeout := pollTimeout
+	if m.period > 0 && m.period < timeout {
+		timeout = m.period
+	}
+	var next time.Time
+	for {
+		select {
+		case <-m.Done:
+			return
+		default:
+		}
+		if len(fds) > 0 {
+			err := ppoll(fds, timeout)
+			if err != nil && err != syscall.EINTR {
+				if !m.sendErr(fmt.Errorf("pressure monitor: poll: %s", err)) {
+					return
+				}
+				continue
+			}
+			for i := range fds {
+				if fds[i].revents == 0 {
+					continue
+				}
+				if fds[i].revents&(pollErr|pollNVal) != 0 {
+					// the trigger can no longer be used; stop polling it.
+					fds[i].fd = -1
+					if !m.sendErr(fmt.Errorf("pressure monitor: %s: %s: trigger is no longer valid", m.kernel[i].Resource, m.kernel[i].Trigger)) {
+						return
+					}
+					continue
+				}
+				if !m.send(Event{Timestamp: time.Now().UTC().UnixNano(), Trigger: m.kernel[i].Trigger}) {
+					return
+				}
+			}
+		} else {
+			select {
+			case <-m.Done:
+				return
+			case <-time.After(time.Until(next)):
+			}
+		}
+		if len(m.emulated) > 0 && !time.Now().Before(next) {
+			next = time.Now().Add(m.period)
+			if !m.emulate() {
+				return
+			}
+		}
+	}
+}
+
+// emulate polls the totals and checks the emulated triggers. A false is
+// returned if the monitor was stopped.
+func (m *Monitor) emulate() bool {
+	p, err := m.prof.Get()
+	if err != nil {
+		return m.sendErr(err)
+	}
+	for i := range m.emulated {
+		e := &m.emulated[i]
+		st := &p.Resource(e.Resource).Some
+		if e.Kind == Full {
+			st = &p.Resource(e.Resource).Full
+		}
+		stall, ok := e.add(p.Timestamp, st.Total)
+		if !ok {
+			continue
+		}
+		if !m.send(Event{Timestamp: p.Timestamp, Trigger: e.Trigger, Emulated: true, Stall: stall}) {
+			return false
+		}
+	}
+	return true
+}
+
+// add adds a sample of the total stall time, in microseconds, at ts and
+// returns the stall time within the window. A true is returned if the
+// threshold was exceeded and an event should be triggered.
+func (e *emulatedTrigger) add(ts, total int64) (int64, bool) {
+	e.samples = append(e.samples, sample{ts: ts, total: total})
+	// keep the newest sample that is at, or before, the start of the window.
+	start := ts - int64(e.Window)
+	for len(e.samples) > 1 && e.samples[1].ts <= start {
+		e.samples = e.samples[1:]
+	}
+	if total < e.samples[0].total {
+		// the total started over; start over with the current sample.
+		e.samples = e.samples[len(e.samples)-1:]
+		return 0, false
+	}
+	stall := total - e.samples[0].total
+	if stall < int64(e.Threshold/time.Microsecond) || ts-e.last < int64(e.Window) {
+		return stall, false
+	}
+	e.last = ts
+	return stall, true
+}
+
+// send sends the event. A false is returned if the monitor was stopped
+// instead.
+func (m *Monitor) send(ev Event) bool {
+	select {
+	case m.Events <- ev:
+		return true
+	case <-m.Done:
+		return false
+	}
+}
+
+// sendErr sends the error. A false is returned if the monitor was stopped
+// instead.
+func (m *Monitor) sendErr(err error) bool {
+	select {
+	case m.Errs <- err:
+		return true
+	case <-m.Done:
+		return false
+	}
+}
+
+// Stop sends a signal to the done channel; stopping the Monitor. Stop returns
+// after the Monitor has stopped running, so it can be restarted with Run.
+func (m *Monitor) Stop() {
+	m.Done <- struct{}{}
+	<-m.stopped
+}
+
+// Close stops the monitor, unregisters the triggers, and closes the Events
+// and Errs channels.
+func (m *Monitor) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	m.closed = true
+	close(m.Done)
+	// if the monitor is running, it closes everything when it returns.
+	if !m.running {
+		m.close()
+	}
+}
+
+// close unregisters the triggers and closes the channels.
+func (m *Monitor) close() {
+	m.unregister()
+	if m.ownsProf {
+		m.prof.Close()
+	}
+	close(m.Events)
+	close(m.Errs)
+}
+
+// pollFd is struct pollfd.
+type pollFd struct {
+	fd      int32
+	events  int16
+	revents int16
+}
+
+// poll events.
+const (
+	pollPri  = 0x2
+	pollErr  = 0x8
+	pollNVal = 0x20
+)
+
+// ppoll waits, up to timeout, for events on the fds; fds with events have
+// their revents set.
+func ppoll(fds []pollFd, timeout time.Duration) error {
+	ts := syscall.NsecToTimespec(int64(timeout))
+	_, _, errno := syscall.Syscall6(syscall.SYS_PPOLL, uintptr(unsafe.Pointer(&fds[0])), uintptr(len(fds)), uintptr(unsafe.Pointer(&ts)), 0, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}